@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/modelcontextprotocol/mcpclient"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/llmcontext/gomcp/version"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// options shared by the commands talking to a server
+type clientOptions struct {
+	timeout time.Duration
+	verbose bool
+
+	// the positional arguments and the server command line
+	positional    []string
+	serverCommand []string
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *clientOptions) {
+	options := &clientOptions{}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.DurationVar(&options.timeout, "timeout", defaultTimeout, "maximum duration of the command")
+	fs.BoolVar(&options.verbose, "verbose", false, "print the client logs and the server notifications")
+	return fs, options
+}
+
+// parseArgs parses the flags, which may appear between the positional
+// arguments, and splits the server command line after "--"
+func parseArgs(fs *flag.FlagSet, options *clientOptions, args []string, positional ...string) error {
+	for i, arg := range args {
+		if arg == "--" {
+			options.serverCommand = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return newUsageError("%v", err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		options.positional = append(options.positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(options.positional) != len(positional) {
+		if len(positional) == 0 {
+			return newUsageError("unexpected argument %s", options.positional[0])
+		}
+		return newUsageError("expected %s", strings.Join(positional, ", "))
+	}
+	if len(options.serverCommand) == 0 {
+		return newUsageError("missing server command after --")
+	}
+	return nil
+}
+
+// connect starts the server and initializes the session, the returned
+// function stops the server
+func connect(options *clientOptions, stderr io.Writer) (context.Context, *mcpclient.McpClient, func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+
+	logOutput := io.Discard
+	if options.verbose {
+		logOutput = stderr
+	}
+	clientLogger := logger.FromSlog(slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+
+	serverTransport := newTransport(options, stderr, clientLogger)
+
+	client := mcpclient.NewMcpClient("gomcp", version.Version, clientLogger)
+	if options.verbose {
+		client.OnNotification(func(method string, params *jsonrpc.JsonRpcParams) {
+			if params == nil {
+				fmt.Fprintf(stderr, "notification %s\n", method)
+				return
+			}
+			fmt.Fprintf(stderr, "notification %s %s\n", method, params.String())
+		})
+	}
+
+	stop := func() {
+		client.Close()
+		cancel()
+	}
+
+	serverInfo, err := client.Connect(ctx, serverTransport)
+	if err != nil {
+		stop()
+		return nil, nil, nil, fmt.Errorf("failed to connect to %s: %v", options.serverCommand[0], err)
+	}
+	if options.verbose {
+		fmt.Fprintf(stderr, "connected to %s %s (protocol %s)\n",
+			serverInfo.ServerInfo.Name, serverInfo.ServerInfo.Version, serverInfo.ProtocolVersion)
+	}
+
+	return ctx, client, stop, nil
+}
+
+// newTransport starts the server command as a child process, the tests
+// replace it to run the server in the same process
+var newTransport = func(options *clientOptions, stderr io.Writer, logger types.Logger) types.Transport {
+	commandTransport := transport.NewCommandTransport(options.serverCommand[0], options.serverCommand[1:], logger)
+	commandTransport.SetStderr(stderr)
+	return commandTransport
+}
+
+func printJson(stdout io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// keyValueFlag collects the values of a repeated key=value flag
+type keyValueFlag map[string]string
+
+func (f keyValueFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for key, value := range f {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f keyValueFlag) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[key] = val
+	return nil
+}
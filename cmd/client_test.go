@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientTransport is the client side of an in-process transport
+type clientTransport struct {
+	server    *transport.InProcessTransport
+	onStarted func()
+	onMessage func(json.RawMessage)
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (t *clientTransport) Start(ctx context.Context) error {
+	if t.onStarted != nil {
+		t.onStarted()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.done:
+			return fmt.Errorf("closed")
+		case message := <-t.server.Outgoing():
+			t.onMessage(message)
+		}
+	}
+}
+
+func (t *clientTransport) Send(message json.RawMessage) error {
+	return t.server.Deliver(message)
+}
+
+func (t *clientTransport) Close() {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.server.Close()
+	})
+}
+
+func (t *clientTransport) OnStarted(callback func())                { t.onStarted = callback }
+func (t *clientTransport) OnMessage(callback func(json.RawMessage)) { t.onMessage = callback }
+func (t *clientTransport) OnClose(callback func())                  {}
+func (t *clientTransport) OnError(callback func(error))             {}
+
+type echoContext struct{}
+
+func echoInit(ctx context.Context) (*echoContext, error) {
+	return &echoContext{}, nil
+}
+
+type echoInput struct {
+	Text string `json:"text" jsonschema_description:"the text to echo"`
+}
+
+func echo(ctx context.Context, toolCtx *echoContext, input *echoInput, output types.ToolCallResult) error {
+	if input.Text == "" {
+		output.SetError(true)
+	}
+	output.AddTextContent("echo " + input.Text)
+	return nil
+}
+
+type greetInput struct {
+	Name string `json:"name" jsonschema_description:"who to greet"`
+}
+
+func greet(ctx context.Context, toolCtx *echoContext, input *greetInput, output types.PromptGetResult) error {
+	output.AddTextContent(types.RoleUser, "Hello "+input.Name)
+	return nil
+}
+
+// inProcessServer replaces the server command with a server running in
+// the test, the command lines passed to the transport are recorded
+func inProcessServer(t *testing.T) *[][]string {
+	serverCommands := [][]string{}
+	previous := newTransport
+	t.Cleanup(func() { newTransport = previous })
+
+	newTransport = func(options *clientOptions, stderr io.Writer, logger types.Logger) types.Transport {
+		serverCommands = append(serverCommands, options.serverCommand)
+
+		definition := gomcp.NewMcpServerDefinition("echo", "0.0.1")
+		tools := definition.WithTools(nil, echoInit)
+		assert.NoError(t, tools.AddTool("echo", "Echoes a text", echo))
+		assert.NoError(t, definition.AddPrompt("greet", "Greets someone", greet))
+		server, err := gomcp.NewModelContextProtocolServer(definition)
+		assert.NoError(t, err)
+
+		serverTransport := transport.NewInProcessTransport()
+		go server.Start(serverTransport)
+		return &clientTransport{server: serverTransport, done: make(chan struct{})}
+	}
+	return &serverCommands
+}
+
+func TestClientCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		// nil if the server must not be started
+		serverCommand []string
+		exitCode      int
+		stdout        string
+		stderr        string
+	}{
+		{
+			name:          "tools list",
+			args:          []string{"tools", "list", "--", "./server", "--config", "gomcp.json"},
+			serverCommand: []string{"./server", "--config", "gomcp.json"},
+			stdout:        `"name": "echo"`,
+		},
+		{
+			name:          "tools call with flags after the tool",
+			args:          []string{"tools", "call", "echo", "--args", `{"text":"hi"}`, "--timeout", "5s", "--", "./server"},
+			serverCommand: []string{"./server"},
+			stdout:        `"text": "echo hi"`,
+		},
+		{
+			name:          "tools call reporting an error",
+			args:          []string{"tools", "call", "echo", "--args", `{"text":""}`, "--", "./server"},
+			serverCommand: []string{"./server"},
+			exitCode:      1,
+			stdout:        `"isError": true`,
+			stderr:        "tool echo reported an error",
+		},
+		{
+			name:          "tools call of an unknown tool",
+			args:          []string{"tools", "call", "unknown", "--", "./server"},
+			serverCommand: []string{"./server"},
+			exitCode:      1,
+			stderr:        "unknown not found",
+		},
+		{
+			name:     "tools call with invalid arguments",
+			args:     []string{"tools", "call", "echo", "--args", "[1]", "--", "./server"},
+			exitCode: 2,
+			stderr:   "--args must be a JSON object",
+		},
+		{
+			name:     "tools call without tool",
+			args:     []string{"tools", "call", "--", "./server"},
+			exitCode: 2,
+			stderr:   "expected <tool>",
+		},
+		{
+			name:     "unexpected argument",
+			args:     []string{"tools", "list", "extra", "--", "./server"},
+			exitCode: 2,
+			stderr:   "unexpected argument extra",
+		},
+		{
+			name:     "missing server command",
+			args:     []string{"tools", "list"},
+			exitCode: 2,
+			stderr:   "missing server command after --",
+		},
+		{
+			name:     "invalid timeout",
+			args:     []string{"resources", "list", "--timeout", "soon", "--", "./server"},
+			exitCode: 2,
+			stderr:   "invalid value \"soon\"",
+		},
+		{
+			name:          "prompts list",
+			args:          []string{"prompts", "list", "--", "./server"},
+			serverCommand: []string{"./server"},
+			stdout:        `"name": "greet"`,
+		},
+		{
+			name:          "prompts get",
+			args:          []string{"prompts", "get", "greet", "-a", "name=Ada", "--", "./server"},
+			serverCommand: []string{"./server"},
+			stdout:        `"text": "Hello Ada"`,
+		},
+		{
+			name:     "prompts get with an invalid argument",
+			args:     []string{"prompts", "get", "greet", "-a", "name", "--", "./server"},
+			exitCode: 2,
+			stderr:   "expected key=value",
+		},
+		{
+			name:          "resources read of an unknown resource",
+			args:          []string{"resources", "read", "file:///unknown", "--", "./server"},
+			serverCommand: []string{"./server"},
+			exitCode:      1,
+			stderr:        "gomcp resources read:",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serverCommands := inProcessServer(t)
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			exitCode := run(test.args, stdout, stderr)
+
+			assert.Equal(t, test.exitCode, exitCode, stderr.String())
+			assert.Contains(t, stdout.String(), test.stdout)
+			assert.Contains(t, stderr.String(), test.stderr)
+			if test.serverCommand == nil {
+				assert.Empty(t, *serverCommands)
+			} else {
+				assert.Equal(t, [][]string{test.serverCommand}, *serverCommands)
+			}
+		})
+	}
+}
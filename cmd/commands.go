@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/llmcontext/gomcp/pkg/prompts"
+	"github.com/llmcontext/gomcp/pkg/trace"
+)
+
+func toolsList(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("tools list")
+	if err := parseArgs(fs, options, args); err != nil {
+		return err
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.ListTools(ctx)
+	if err != nil {
+		return err
+	}
+	return printJson(stdout, result)
+}
+
+func toolsCall(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("tools call")
+	toolArgs := fs.String("args", "{}", "the arguments of the tool, as a JSON object")
+	if err := parseArgs(fs, options, args, "<tool>"); err != nil {
+		return err
+	}
+
+	arguments := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(*toolArgs), &arguments); err != nil {
+		return newUsageError("--args must be a JSON object: %v", err)
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.CallTool(ctx, options.positional[0], arguments)
+	if err != nil {
+		return err
+	}
+	if err := printJson(stdout, result); err != nil {
+		return err
+	}
+	if result.IsError != nil && *result.IsError {
+		return fmt.Errorf("tool %s reported an error", options.positional[0])
+	}
+	return nil
+}
+
+func promptsList(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("prompts list")
+	if err := parseArgs(fs, options, args); err != nil {
+		return err
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.ListPrompts(ctx)
+	if err != nil {
+		return err
+	}
+	return printJson(stdout, result)
+}
+
+func promptsGet(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("prompts get")
+	arguments := keyValueFlag{}
+	fs.Var(arguments, "a", "an argument of the prompt, as key=value, can be repeated")
+	if err := parseArgs(fs, options, args, "<prompt>"); err != nil {
+		return err
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.GetPrompt(ctx, options.positional[0], arguments)
+	if err != nil {
+		return err
+	}
+	return printJson(stdout, result)
+}
+
+// promptsLint validates prompt files without starting a server
+func promptsLint(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("prompts lint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	strict := fs.Bool("strict", false, "fail on warnings")
+	if err := fs.Parse(args); err != nil {
+		return newUsageError("%v", err)
+	}
+	if fs.NArg() == 0 {
+		return newUsageError("expected <prompts file>")
+	}
+
+	issues, err := prompts.ValidatePromptFiles(fs.Args()...)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue.String())
+		if issue.Severity == prompts.SeverityError || *strict {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d problem(s) found", failed)
+	}
+	return nil
+}
+
+func resourcesList(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("resources list")
+	if err := parseArgs(fs, options, args); err != nil {
+		return err
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.ListResources(ctx)
+	if err != nil {
+		return err
+	}
+	return printJson(stdout, result)
+}
+
+func resourcesRead(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, options := newFlagSet("resources read")
+	if err := parseArgs(fs, options, args, "<uri>"); err != nil {
+		return err
+	}
+
+	ctx, client, stop, err := connect(options, stderr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	result, err := client.ReadResource(ctx, options.positional[0])
+	if err != nil {
+		return err
+	}
+	return printJson(stdout, result)
+}
+
+// tracePrint prints a file recorded with the protocolDebugFile option
+func tracePrint(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) != 1 {
+		return newUsageError("expected <trace file>")
+	}
+	entries, err := trace.ReadTraceFile(args[0])
+	if err != nil {
+		return err
+	}
+	return trace.PrettyPrint(stdout, entries)
+}
@@ -1,58 +1,103 @@
+// The gomcp command calls, lists and inspects any MCP server from the
+// terminal. The server is started as a child process and the messages
+// are exchanged through its standard input and output.
 package main
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
-	"github.com/llmcontext/gomcp"
-	"github.com/llmcontext/gomcp/types"
+	"github.com/llmcontext/gomcp/version"
 )
 
-type DummyToolConfiguration struct {
-	Name string
-}
+const usage = `gomcp - call, list and inspect MCP servers
+
+Usage:
+  gomcp tools list [options] -- <server command> [args...]
+  gomcp tools call <tool> [--args '{...}'] [options] -- <server command> [args...]
+  gomcp prompts list [options] -- <server command> [args...]
+  gomcp prompts get <prompt> [-a key=value ...] [options] -- <server command> [args...]
+  gomcp prompts lint [--strict] <prompts file>...
+  gomcp resources list [options] -- <server command> [args...]
+  gomcp resources read <uri> [options] -- <server command> [args...]
+  gomcp trace print <trace file>
+  gomcp version
+
+Options:
+  --timeout duration   maximum duration of the command (default 30s)
+  --verbose            print the client logs and the server notifications on stderr
+
+The results are printed as JSON on stdout. The exit code is 1 if the
+request fails or if the tool reports an error, 2 if the command line is invalid.
 
-type DummyContext struct {
-	Name string
+prompts lint prints the problems found in the prompt files and exits with
+code 1 if there is an error, or a warning when --strict is set.
+`
+
+// a command receives the arguments following its name
+type command func(args []string, stdout io.Writer, stderr io.Writer) error
+
+var commands = map[string]command{
+	"tools list":     toolsList,
+	"tools call":     toolsCall,
+	"prompts list":   promptsList,
+	"prompts get":    promptsGet,
+	"prompts lint":   promptsLint,
+	"resources list": resourcesList,
+	"resources read": resourcesRead,
+	"trace print":    tracePrint,
 }
 
-func DummyToolInit(ctx context.Context, config *DummyToolConfiguration) (*DummyContext, error) {
-	return &DummyContext{
-		Name: config.Name,
-	}, nil
+// usageError is reported with the usage of the command
+type usageError struct {
+	message string
 }
 
-type DummyPingInput struct {
-	Message string `json:"message" jsonschema_description:"the message to ping."`
+func (e *usageError) Error() string {
+	return e.message
 }
 
-func DummyPing(ctx context.Context, toolCtx *DummyContext, input *DummyPingInput, output types.ToolCallResult) error {
-	output.AddTextContent(fmt.Sprintf("pong %s from %s", input.Message, toolCtx.Name))
-	return nil
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
 }
 
 func main() {
-	// create the mcpServerDefinition
-	mcpServerDefinition := gomcp.NewMcpServerDefinition("dummy", "0.0.1")
-	mcpServerDefinition.SetDebugLevel("debug", "debug.log")
-
-	mcpToolsDefinition := mcpServerDefinition.WithTools(&DummyToolConfiguration{
-		Name: "dummy",
-	}, DummyToolInit)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	mcpToolsDefinition.AddTool("ping", "A ping function", DummyPing)
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	if args[0] == "version" {
+		fmt.Fprintf(stdout, "gomcp %s\n", version.Version)
+		return 0
+	}
 
-	mcp, err := gomcp.NewModelContextProtocolServer(mcpServerDefinition)
-	if err != nil {
-		fmt.Println("Error creating MCP server:", err)
-		os.Exit(1)
+	if len(args) < 2 {
+		fmt.Fprintf(stderr, "unknown command: %s\n\n%s", args[0], usage)
+		return 2
+	}
+	name := args[0] + " " + args[1]
+	cmd, found := commands[name]
+	if !found {
+		fmt.Fprintf(stderr, "unknown command: %s\n\n%s", strings.TrimSpace(name), usage)
+		return 2
 	}
-	// start the server
-	transport := mcp.StdioTransport()
-	err = mcp.Start(transport)
+
+	err := cmd(args[2:], stdout, stderr)
 	if err != nil {
-		fmt.Println("Error starting MCP server:", err)
-		os.Exit(1)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "gomcp %s: %v\n\n%s", name, err, usage)
+			return 2
+		}
+		fmt.Fprintf(stderr, "gomcp %s: %v\n", name, err)
+		return 1
 	}
+	return 0
 }
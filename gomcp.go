@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/llmcontext/gomcp/modelcontextprotocol/mcpserver"
+	"github.com/llmcontext/gomcp/pkg/config"
 	"github.com/llmcontext/gomcp/providers/sdk"
 	"github.com/llmcontext/gomcp/types"
 )
@@ -19,3 +20,25 @@ func NewModelContextProtocolServer(definition types.McpSdkServerDefinition) (typ
 	}
 	return mcp, nil
 }
+
+// NewToolProvider declares a named set of tools, the name is used
+// to find the provider configuration in the configuration file
+func NewToolProvider(providerName string, toolsInitFunction interface{}) types.ToolProvider {
+	return sdk.NewToolProvider(providerName, toolsInitFunction)
+}
+
+// NewServerFromConfigFile creates a server from a JSON configuration file
+// and binds each tool provider to its configuration
+func NewServerFromConfigFile(configFilePath string, toolProviders ...types.ToolProvider) (types.ModelContextProtocolServer, error) {
+	serverConfig, err := config.LoadServerConfigurationFile(configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	definition, err := sdk.NewMcpSdkServerDefinitionFromConfiguration(serverConfig, toolProviders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	return NewModelContextProtocolServer(definition)
+}
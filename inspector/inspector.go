@@ -0,0 +1,349 @@
+// Package inspector serves a web page showing the messages exchanged
+// with the client, the tools and prompts of the server, and a form
+// to call the tools. The API requires a random token, generated for
+// each run and printed on stderr with the URL of the page.
+package inspector
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/modelcontextprotocol"
+	"github.com/llmcontext/gomcp/pkg/trace"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+const (
+	// number of messages kept for the clients connecting later
+	maxHistory = 500
+	// number of messages buffered for each live subscriber
+	subscriberBufferSize = 100
+	shutdownTimeout      = 5 * time.Second
+	// the token is sent in this header, or in the token query
+	// parameter for the event stream
+	tokenHeader = "X-Inspector-Token"
+)
+
+type Inspector struct {
+	listenAddress string
+	handler       modelcontextprotocol.McpServerEventHandler
+	logger        types.Logger
+	// required by the api, generated for each run
+	token string
+
+	mu          sync.Mutex
+	history     []*trace.Entry
+	subscribers map[chan *trace.Entry]struct{}
+}
+
+func NewInspector(listenAddress string, handler modelcontextprotocol.McpServerEventHandler, logger types.Logger) *Inspector {
+	return &Inspector{
+		listenAddress: listenAddress,
+		handler:       handler,
+		logger:        logger,
+		token:         newToken(),
+		history:       []*trace.Entry{},
+		subscribers:   make(map[chan *trace.Entry]struct{}),
+	}
+}
+
+// Start serves the inspector until the context is cancelled
+func (i *Inspector) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", i.listenAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:     i.routes(),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		// the token is not logged, the log file may be shared
+		i.logger.Info("inspector listening", types.LogArg{
+			"address": listener.Addr().String(),
+		})
+		// stdout is used by the stdio transport
+		fmt.Fprintf(os.Stderr, "gomcp inspector: http://%s/?token=%s\n", listener.Addr().String(), i.token)
+		errChan <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+		return ctx.Err()
+	}
+}
+
+// Write receives the lines of a protocol trace, so that the inspector
+// can be plugged in a transport.RecordingTransport
+func (i *Inspector) Write(line []byte) (int, error) {
+	var entry trace.Entry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return 0, err
+	}
+	i.EnqueueMessage(&entry)
+	return len(line), nil
+}
+
+// EnqueueMessage adds a message to the history and sends it to the live subscribers
+func (i *Inspector) EnqueueMessage(entry *trace.Entry) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.history = append(i.history, entry)
+	if len(i.history) > maxHistory {
+		i.history = i.history[len(i.history)-maxHistory:]
+	}
+	for subscriber := range i.subscribers {
+		select {
+		case subscriber <- entry:
+		default:
+			// slow subscriber, the message is dropped
+		}
+	}
+}
+
+func (i *Inspector) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	static, _ := fs.Sub(staticFiles, "static")
+	mux.Handle("GET /", http.FileServer(http.FS(static)))
+	mux.HandleFunc("GET /api/messages", i.handleMessages)
+	mux.HandleFunc("GET /api/events", i.handleEvents)
+	mux.HandleFunc("GET /api/tools", i.handleToolsList)
+	mux.HandleFunc("GET /api/prompts", i.handlePromptsList)
+	mux.HandleFunc("POST /api/tools/call", i.handleToolsCall)
+
+	return i.guard(mux)
+}
+
+// guard rejects the requests coming from other sites, eg. through DNS
+// rebinding, and the api requests without the token of the run
+func (i *Inspector) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !i.isAllowedHost(r.Host) {
+			http.Error(w, "invalid host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			originUrl, err := url.Parse(origin)
+			if err != nil || originUrl.Host != r.Host {
+				http.Error(w, "invalid origin", http.StatusForbidden)
+				return
+			}
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			token := r.Header.Get(tokenHeader)
+			if token == "" {
+				token = r.URL.Query().Get("token")
+			}
+			if subtle.ConstantTimeCompare([]byte(token), []byte(i.token)) != 1 {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAllowedHost accepts the loopback addresses and the host the
+// inspector listens on, if it was set explicitly
+func (i *Inspector) isAllowedHost(hostPort string) bool {
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host = hostPort
+	}
+	if host == "localhost" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	listenHost, _, err := net.SplitHostPort(i.listenAddress)
+	if err != nil || listenHost == "" {
+		return false
+	}
+	if ip := net.ParseIP(listenHost); ip != nil && ip.IsUnspecified() {
+		return false
+	}
+	return strings.EqualFold(host, listenHost)
+}
+
+func (i *Inspector) handleMessages(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	history := make([]*trace.Entry, len(i.history))
+	copy(history, i.history)
+	i.mu.Unlock()
+
+	writeJson(w, http.StatusOK, history)
+}
+
+// handleEvents streams the new messages as server-sent events
+func (i *Inspector) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	subscriber := make(chan *trace.Entry, subscriberBufferSize)
+	i.mu.Lock()
+	i.subscribers[subscriber] = struct{}{}
+	i.mu.Unlock()
+	defer func() {
+		i.mu.Lock()
+		delete(i.subscribers, subscriber)
+		i.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case entry := <-subscriber:
+			data, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			if _, err := w.Write([]byte("data: " + string(data) + "\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// the inspector shows all the tools and prompts, the pages are concatenated
+func (i *Inspector) handleToolsList(w http.ResponseWriter, r *http.Request) {
+	result := &mcp.JsonRpcResponseToolsListResult{Tools: []mcp.ToolDescription{}}
+	params := &mcp.JsonRpcRequestToolsListParams{}
+	for {
+		page, rpcErr := i.handler.ExecuteToolsList(r.Context(), params, i.logger)
+		if rpcErr != nil {
+			writeRpcError(w, rpcErr)
+			return
+		}
+		result.Tools = append(result.Tools, page.Tools...)
+		if page.NextCursor == nil {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+func (i *Inspector) handlePromptsList(w http.ResponseWriter, r *http.Request) {
+	result := &mcp.JsonRpcResponsePromptsListResult{Prompts: []mcp.PromptDescription{}}
+	params := &mcp.JsonRpcRequestPromptsListParams{}
+	for {
+		page, rpcErr := i.handler.ExecutePromptsList(r.Context(), params, i.logger)
+		if rpcErr != nil {
+			writeRpcError(w, rpcErr)
+			return
+		}
+		result.Prompts = append(result.Prompts, page.Prompts...)
+		if page.NextCursor == nil {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+func (i *Inspector) handleToolsCall(w http.ResponseWriter, r *http.Request) {
+	// a form posted by another site can't set this content type
+	// without a preflight request
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	params := &mcp.JsonRpcRequestToolsCallParams{}
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		writeRpcError(w, &jsonrpc.JsonRpcError{Code: jsonrpc.RpcParseError, Message: err.Error()})
+		return
+	}
+	if params.Name == "" {
+		writeRpcError(w, &jsonrpc.JsonRpcError{Code: jsonrpc.RpcInvalidParams, Message: "missing name"})
+		return
+	}
+	if params.Arguments == nil {
+		params.Arguments = map[string]interface{}{}
+	}
+
+	i.logger.Info("inspector tool call", types.LogArg{
+		"toolName": params.Name,
+	})
+	result, rpcErr := i.handler.ExecuteToolCall(r.Context(), params, i.logger)
+	if rpcErr != nil {
+		writeRpcError(w, rpcErr)
+		return
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+func writeRpcError(w http.ResponseWriter, rpcErr *jsonrpc.JsonRpcError) {
+	status := http.StatusInternalServerError
+	if rpcErr.Code == jsonrpc.RpcParseError || rpcErr.Code == jsonrpc.RpcInvalidParams {
+		status = http.StatusBadRequest
+	}
+	writeJson(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    rpcErr.Code,
+			"message": rpcErr.Message,
+		},
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func newToken() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		// the api can't be exposed without a token
+		panic(err)
+	}
+	return hex.EncodeToString(buffer)
+}
+
+// IsClosed returns true if the error is the result of a normal shutdown
+func IsClosed(err error) bool {
+	return errors.Is(err, http.ErrServerClosed) || errors.Is(err, context.Canceled)
+}
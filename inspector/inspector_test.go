@@ -0,0 +1,149 @@
+package inspector
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/providers/results"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubHandler struct {
+	calls []*mcp.JsonRpcRequestToolsCallParams
+}
+
+func (h *stubHandler) ExecuteToolsList(ctx context.Context, params *mcp.JsonRpcRequestToolsListParams, logger types.Logger) (*mcp.JsonRpcResponseToolsListResult, *jsonrpc.JsonRpcError) {
+	return &mcp.JsonRpcResponseToolsListResult{
+		Tools: []mcp.ToolDescription{{Name: "echo", Description: "echo the message"}},
+	}, nil
+}
+
+func (h *stubHandler) ExecuteToolCall(ctx context.Context, params *mcp.JsonRpcRequestToolsCallParams, logger types.Logger) (types.ToolCallResult, *jsonrpc.JsonRpcError) {
+	h.calls = append(h.calls, params)
+	if params.Name != "echo" {
+		return nil, &jsonrpc.JsonRpcError{Code: jsonrpc.RpcInvalidParams, Message: "unknown tool"}
+	}
+	result := results.NewToolCallResult()
+	result.AddTextContent(params.Arguments["message"].(string))
+	return result, nil
+}
+
+func (h *stubHandler) ExecutePromptsList(ctx context.Context, params *mcp.JsonRpcRequestPromptsListParams, logger types.Logger) (*mcp.JsonRpcResponsePromptsListResult, *jsonrpc.JsonRpcError) {
+	return &mcp.JsonRpcResponsePromptsListResult{}, nil
+}
+
+func (h *stubHandler) ExecutePromptGet(ctx context.Context, params *mcp.JsonRpcRequestPromptsGetParams, logger types.Logger) (types.PromptGetResult, *jsonrpc.JsonRpcError) {
+	return nil, &jsonrpc.JsonRpcError{Code: jsonrpc.RpcInvalidParams, Message: "unknown prompt"}
+}
+
+func (h *stubHandler) ExecuteCompletion(ctx context.Context, params *mcp.JsonRpcRequestCompletionCompleteParams, logger types.Logger) (*mcp.JsonRpcResponseCompletionCompleteResult, *jsonrpc.JsonRpcError) {
+	return nil, &jsonrpc.JsonRpcError{Code: jsonrpc.RpcMethodNotFound, Message: "not implemented"}
+}
+
+func newTestInspector() (*Inspector, *stubHandler, *httptest.Server) {
+	handler := &stubHandler{}
+	inspector := NewInspector("", handler, logger.FromSlog(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	return inspector, handler, httptest.NewServer(inspector.routes())
+}
+
+// request sends an api request with the token of the inspector
+func request(t *testing.T, inspector *Inspector, method string, url string, contentType string, body string) *http.Response {
+	r, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.NoError(t, err)
+	r.Header.Set(tokenHeader, inspector.token)
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	response, err := http.DefaultClient.Do(r)
+	assert.NoError(t, err)
+	return response
+}
+
+func TestInspectorToolsCall(t *testing.T) {
+	inspector, handler, server := newTestInspector()
+	defer server.Close()
+
+	response := request(t, inspector, http.MethodPost, server.URL+"/api/tools/call", "application/json",
+		`{"name":"echo","arguments":{"message":"hello"}}`)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result map[string]interface{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "text", "text": "hello"}}, result["content"])
+	assert.Len(t, handler.calls, 1)
+
+	response = request(t, inspector, http.MethodPost, server.URL+"/api/tools/call", "application/json",
+		`{"name":"unknown"}`)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestInspectorRejectsForeignRequests(t *testing.T) {
+	inspector, handler, server := newTestInspector()
+	defer server.Close()
+	call := `{"name":"echo","arguments":{"message":"hello"}}`
+
+	// missing token
+	response, err := http.Post(server.URL+"/api/tools/call", "application/json", strings.NewReader(call))
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	// a form posted by another site
+	response = request(t, inspector, http.MethodPost, server.URL+"/api/tools/call", "text/plain", call)
+	response.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.StatusCode)
+
+	// foreign origin
+	r, _ := http.NewRequest(http.MethodPost, server.URL+"/api/tools/call", strings.NewReader(call))
+	r.Header.Set(tokenHeader, inspector.token)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Origin", "http://evil.example")
+	response, err = http.DefaultClient.Do(r)
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	// dns rebinding, the host is not the inspector one
+	r, _ = http.NewRequest(http.MethodGet, server.URL+"/", nil)
+	r.Host = "evil.example"
+	response, err = http.DefaultClient.Do(r)
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	assert.Len(t, handler.calls, 0)
+}
+
+func TestInspectorMessages(t *testing.T) {
+	inspector, _, server := newTestInspector()
+	defer server.Close()
+
+	_, err := inspector.Write([]byte(`{"timestamp":"2024-12-01T10:00:00Z","direction":"in","sessionId":"abc","message":{"jsonrpc":"2.0","id":1,"method":"ping"}}` + "\n"))
+	assert.NoError(t, err)
+
+	response := request(t, inspector, http.MethodGet, server.URL+"/api/messages", "", "")
+	defer response.Body.Close()
+
+	var entries []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&entries))
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "abc", entries[0]["sessionId"])
+
+	response, err = http.Get(server.URL + "/")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	assert.Contains(t, string(body), "gomcp inspector")
+}
@@ -0,0 +1,202 @@
+"use strict";
+
+const messagesList = document.getElementById("messages");
+const statusLabel = document.getElementById("status");
+// the token of the run, printed by the server with the url of the page
+const token = new URLSearchParams(window.location.search).get("token") || "";
+const apiHeaders = { "X-Inspector-Token": token };
+
+function summary(entry) {
+  const message = entry.message;
+  if (!message) {
+    return "invalid message";
+  }
+  if (Array.isArray(message)) {
+    return `batch of ${message.length} messages`;
+  }
+  const id = message.id === undefined ? null : JSON.stringify(message.id);
+  if (message.method !== undefined && id !== null) {
+    return `request ${message.method} id=${id}`;
+  }
+  if (message.method !== undefined) {
+    return `notification ${message.method}`;
+  }
+  if (message.error) {
+    return `error response id=${id}: ${message.error.code} ${message.error.message}`;
+  }
+  return `response id=${id}`;
+}
+
+function addMessage(entry) {
+  const item = document.createElement("li");
+  item.className = entry.direction;
+  const details = document.createElement("details");
+  const title = document.createElement("summary");
+  const arrow = entry.direction === "in" ? ">>" : "<<";
+  title.textContent = `${entry.timestamp} ${arrow} ${summary(entry)}`;
+  const body = document.createElement("pre");
+  body.textContent = entry.message ? JSON.stringify(entry.message, null, 2) : entry.raw;
+  details.append(title, body);
+  item.append(details);
+  messagesList.append(item);
+}
+
+async function loadMessages() {
+  const response = await fetch("api/messages", { headers: apiHeaders });
+  const entries = await response.json();
+  entries.forEach(addMessage);
+}
+
+function listenToMessages() {
+  const events = new EventSource(`api/events?token=${encodeURIComponent(token)}`);
+  events.onopen = () => {
+    statusLabel.textContent = "live";
+  };
+  events.onerror = () => {
+    statusLabel.textContent = "disconnected";
+  };
+  events.onmessage = (event) => {
+    addMessage(JSON.parse(event.data));
+  };
+}
+
+// builds an input for each property of the tool input schema
+function inputForProperty(name, property, required) {
+  const wrapper = document.createElement("div");
+  const label = document.createElement("label");
+  label.textContent = name + (required ? " *" : "");
+  if (property.description) {
+    const description = document.createElement("span");
+    description.className = "description";
+    description.textContent = " " + property.description;
+    label.append(description);
+  }
+  wrapper.append(label);
+
+  let input;
+  switch (property.type) {
+    case "boolean":
+      input = document.createElement("input");
+      input.type = "checkbox";
+      break;
+    case "integer":
+    case "number":
+      input = document.createElement("input");
+      input.type = "number";
+      if (property.type === "number") {
+        input.step = "any";
+      }
+      break;
+    case "string":
+      if (Array.isArray(property.enum)) {
+        input = document.createElement("select");
+        property.enum.forEach((value) => {
+          const option = document.createElement("option");
+          option.value = option.textContent = value;
+          input.append(option);
+        });
+      } else {
+        input = document.createElement("input");
+        input.type = "text";
+      }
+      break;
+    default:
+      // objects and arrays are edited as JSON
+      input = document.createElement("textarea");
+      input.rows = 4;
+      input.placeholder = "JSON value";
+  }
+  input.name = name;
+  input.dataset.type = property.type || "json";
+  input.required = required && property.type !== "boolean";
+  wrapper.append(input);
+  return wrapper;
+}
+
+function readArguments(form) {
+  const args = {};
+  for (const input of form.querySelectorAll("[name]")) {
+    const type = input.dataset.type;
+    if (type === "boolean") {
+      args[input.name] = input.checked;
+    } else if (input.value === "") {
+      continue;
+    } else if (type === "integer" || type === "number") {
+      args[input.name] = Number(input.value);
+    } else if (type === "string") {
+      args[input.name] = input.value;
+    } else {
+      args[input.name] = JSON.parse(input.value);
+    }
+  }
+  return args;
+}
+
+function showTool(tool) {
+  const form = document.getElementById("tool-form");
+  const inputs = document.getElementById("tool-inputs");
+  document.getElementById("tool-name").textContent = tool.name;
+  document.getElementById("tool-description").textContent = tool.description;
+  inputs.replaceChildren();
+  const schema = tool.inputSchema || {};
+  const required = schema.required || [];
+  Object.entries(schema.properties || {}).forEach(([name, property]) => {
+    inputs.append(inputForProperty(name, property, required.includes(name)));
+  });
+  form.dataset.tool = tool.name;
+  form.hidden = false;
+  document.getElementById("tool-result").hidden = true;
+}
+
+async function callTool(event) {
+  event.preventDefault();
+  const form = event.target;
+  const result = document.getElementById("tool-result");
+  result.hidden = false;
+  let args;
+  try {
+    args = readArguments(form);
+  } catch (error) {
+    result.textContent = `invalid argument: ${error.message}`;
+    return;
+  }
+  result.textContent = "calling...";
+  const response = await fetch("api/tools/call", {
+    method: "POST",
+    headers: { ...apiHeaders, "Content-Type": "application/json" },
+    body: JSON.stringify({ name: form.dataset.tool, arguments: args }),
+  });
+  result.textContent = JSON.stringify(await response.json(), null, 2);
+}
+
+async function loadTools() {
+  const response = await fetch("api/tools", { headers: apiHeaders });
+  const result = await response.json();
+  const list = document.getElementById("tools");
+  (result.tools || []).forEach((tool) => {
+    const item = document.createElement("li");
+    item.textContent = tool.name;
+    item.title = tool.description;
+    item.onclick = () => showTool(tool);
+    list.append(item);
+  });
+}
+
+async function loadPrompts() {
+  const response = await fetch("api/prompts", { headers: apiHeaders });
+  const result = await response.json();
+  const list = document.getElementById("prompts");
+  (result.prompts || []).forEach((prompt) => {
+    const item = document.createElement("li");
+    const args = (prompt.arguments || []).map((argument) => argument.name).join(", ");
+    item.textContent = `${prompt.name}(${args}) - ${prompt.description}`;
+    list.append(item);
+  });
+}
+
+document.getElementById("tool-form").addEventListener("submit", callTool);
+document.getElementById("clear-messages").addEventListener("click", () => messagesList.replaceChildren());
+
+loadMessages().then(listenToMessages);
+loadTools();
+loadPrompts();
@@ -0,0 +1,23 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrEmptyBatch is returned for an empty array, the specification
+// requires a single Invalid Request error in response
+var ErrEmptyBatch = errors.New("empty batch")
+
+// SplitJsonRpcBatch returns the messages of a batch, each message
+// is checked with CheckJsonMessage like a single message
+func SplitJsonRpcBatch(message json.RawMessage) ([]json.RawMessage, error) {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(message, &messages); err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	return messages, nil
+}
@@ -45,6 +45,7 @@ const (
 //
 // a batch request has:
 // - array of request objects
+// its messages are returned by SplitJsonRpcBatch
 
 // this function checks if the message is a valid JsonRpc message
 // and returns the message nature
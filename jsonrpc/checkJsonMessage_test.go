@@ -76,3 +76,24 @@ func TestCheckJsonMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitJsonRpcBatch(t *testing.T) {
+	messages, err := jsonrpc.SplitJsonRpcBatch(json.RawMessage(`[{"jsonrpc": "2.0", "method": "sum", "params": [1, 2], "id": 1}, {"jsonrpc": "2.0", "method": "notify"}, 1]`))
+	if err != nil {
+		t.Fatalf("SplitJsonRpcBatch() error = %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("SplitJsonRpcBatch() messages = %d, want 3", len(messages))
+	}
+	nature, _, err := jsonrpc.CheckJsonMessage(messages[1])
+	if err != nil || nature != jsonrpc.MessageNatureNotification {
+		t.Errorf("CheckJsonMessage() nature = %v, error = %v", nature, err)
+	}
+	if _, _, err := jsonrpc.CheckJsonMessage(messages[2]); err == nil {
+		t.Errorf("CheckJsonMessage() expected an error for a number")
+	}
+
+	if _, err := jsonrpc.SplitJsonRpcBatch(json.RawMessage(`[]`)); err != jsonrpc.ErrEmptyBatch {
+		t.Errorf("SplitJsonRpcBatch() error = %v, want ErrEmptyBatch", err)
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"sync/atomic"
+
+	"github.com/llmcontext/gomcp/pkg/secrets"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// ClientLogger forwards the log entries to the MCP client as
+// notifications/message, the client selects the minimum level
+// with a logging/setLevel request. Nothing is forwarded until
+// the client has set a level.
+type ClientLogger struct {
+	name  string
+	level *atomic.Value
+	send  func(params *mcp.JsonRpcNotificationMessageParams) error
+}
+
+func NewClientLogger(name string, send func(params *mcp.JsonRpcNotificationMessageParams) error) *ClientLogger {
+	level := &atomic.Value{}
+	level.Store(mcp.LoggingLevel(""))
+	return &ClientLogger{
+		name:  name,
+		level: level,
+		send:  send,
+	}
+}
+
+// WithName returns a logger sharing the same level but reporting
+// a different logger name, eg. the name of a tool
+func (l *ClientLogger) WithName(name string) *ClientLogger {
+	return &ClientLogger{
+		name:  name,
+		level: l.level,
+		send:  l.send,
+	}
+}
+
+func (l *ClientLogger) SetLevel(level mcp.LoggingLevel) {
+	l.level.Store(level)
+}
+
+// Level returns the minimum level sent to the client,
+// empty if the client has not set it yet
+func (l *ClientLogger) Level() mcp.LoggingLevel {
+	return l.level.Load().(mcp.LoggingLevel)
+}
+
+func (l *ClientLogger) Info(message string, fields types.LogArg) {
+	l.log(mcp.LoggingLevelInfo, message, fields)
+}
+
+func (l *ClientLogger) Debug(message string, fields types.LogArg) {
+	l.log(mcp.LoggingLevelDebug, message, fields)
+}
+
+func (l *ClientLogger) Error(message string, fields types.LogArg) {
+	l.log(mcp.LoggingLevelError, message, fields)
+}
+
+func (l *ClientLogger) Fatal(message string, fields types.LogArg) {
+	l.log(mcp.LoggingLevelCritical, message, fields)
+}
+
+func (l *ClientLogger) log(level mcp.LoggingLevel, message string, fields types.LogArg) {
+	minLevel := l.Level()
+	if minLevel == "" || level.Severity() < minLevel.Severity() {
+		return
+	}
+
+	data := map[string]interface{}{
+		"message": message,
+	}
+	for key, value := range fields {
+		// errors are marshalled as an empty object
+		if err, ok := value.(error); ok {
+			data[key] = err.Error()
+		} else {
+			data[key] = secrets.Redact(value)
+		}
+	}
+
+	// there is no way to report a failure to send a log entry
+	_ = l.send(&mcp.JsonRpcNotificationMessageParams{
+		Level:  level,
+		Logger: l.name,
+		Data:   data,
+	})
+}
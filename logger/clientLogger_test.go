@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientLoggerLevel(t *testing.T) {
+	sent := []*mcp.JsonRpcNotificationMessageParams{}
+	clientLogger := NewClientLogger("server", func(params *mcp.JsonRpcNotificationMessageParams) error {
+		sent = append(sent, params)
+		return nil
+	})
+	toolLogger := clientLogger.WithName("notion_get_page")
+
+	// nothing is sent before the client sets a level
+	toolLogger.Error("not sent", types.LogArg{})
+	assert.Len(t, sent, 0)
+
+	clientLogger.SetLevel(mcp.LoggingLevelInfo)
+	toolLogger.Debug("not sent", types.LogArg{})
+	toolLogger.Info("page retrieved", types.LogArg{"pageId": "1234"})
+	assert.Len(t, sent, 1)
+	assert.Equal(t, mcp.LoggingLevelInfo, sent[0].Level)
+	assert.Equal(t, "notion_get_page", sent[0].Logger)
+	assert.Equal(t, map[string]interface{}{"message": "page retrieved", "pageId": "1234"}, sent[0].Data)
+
+	// the level is shared with the named loggers
+	clientLogger.SetLevel(mcp.LoggingLevelError)
+	toolLogger.Info("not sent", types.LogArg{})
+	toolLogger.Error("failed", types.LogArg{"error": errors.New("boom")})
+	assert.Len(t, sent, 2)
+	assert.Equal(t, "boom", sent[1].Data.(map[string]interface{})["error"])
+}
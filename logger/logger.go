@@ -3,7 +3,9 @@ package logger
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/llmcontext/gomcp/pkg/secrets"
 	"github.com/llmcontext/gomcp/types"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -13,6 +15,10 @@ type LoggingInfo struct {
 	File       string
 	Level      string
 	WithStderr bool
+	// Append keeps the content of an existing log file,
+	// otherwise it is rotated (if Rotation is enabled) or deleted
+	Append   bool
+	Rotation *RotationInfo
 }
 
 type LoggerImpl struct {
@@ -27,15 +33,6 @@ func NewLogger(config *LoggingInfo, debug bool) (types.Logger, error) {
 	// disable caller to avoid extra noise in the logs (always logger.go anyway)
 	cfg.DisableCaller = true
 
-	// if file path is not absolute, we assume it is a relative path
-	// to the default hub configuration directory
-	// delete output file if present
-	if config.File != "" {
-		if _, err := os.Stat(config.File); err == nil {
-			os.Remove(config.File)
-		}
-	}
-
 	if debug {
 		cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 	} else if config.Level != "" {
@@ -46,18 +43,31 @@ func NewLogger(config *LoggingInfo, debug bool) (types.Logger, error) {
 		cfg.Level = zap.NewAtomicLevelAt(level)
 	}
 
+	outputs := []zapcore.WriteSyncer{}
 	if config.File != "" {
-		cfg.OutputPaths = []string{
-			config.File,
+		logFile, err := OpenRotatingFile(config.File, config.Append, config.Rotation)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		cfg.OutputPaths = []string{}
+		outputs = append(outputs, logFile)
 	}
 	if config.WithStderr {
-		cfg.OutputPaths = append(cfg.OutputPaths, "stderr")
+		outputs = append(outputs, zapcore.Lock(os.Stderr))
 	}
 
-	zapLog := zap.Must(cfg.Build())
+	// same core as the one built by cfg.Build(), but writing
+	// to our log file so that we can rotate it
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(cfg.EncoderConfig),
+		zapcore.NewMultiWriteSyncer(outputs...),
+		cfg.Level,
+	)
+	core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+
+	zapLog := zap.New(core,
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+	)
 	defer zapLog.Sync()
 
 	return &LoggerImpl{
@@ -66,33 +76,27 @@ func NewLogger(config *LoggingInfo, debug bool) (types.Logger, error) {
 }
 
 func (l *LoggerImpl) Info(message string, fields types.LogArg) {
-	zapFields := []zap.Field{}
-	for key, value := range fields {
-		zapFields = append(zapFields, zap.Any(key, value))
-	}
-	l.zapLog.Info(message, zapFields...)
+	l.zapLog.Info(message, toZapFields(fields)...)
 }
 
 func (l *LoggerImpl) Debug(message string, fields types.LogArg) {
-	zapFields := []zap.Field{}
-	for key, value := range fields {
-		zapFields = append(zapFields, zap.Any(key, value))
-	}
-	l.zapLog.Debug(message, zapFields...)
+	l.zapLog.Debug(message, toZapFields(fields)...)
 }
 
 func (l *LoggerImpl) Error(message string, fields types.LogArg) {
-	zapFields := []zap.Field{}
-	for key, value := range fields {
-		zapFields = append(zapFields, zap.Any(key, value))
-	}
-	l.zapLog.Error(message, zapFields...)
+	l.zapLog.Error(message, toZapFields(fields)...)
 }
 
 func (l *LoggerImpl) Fatal(message string, fields types.LogArg) {
+	l.zapLog.Fatal(message, toZapFields(fields)...)
+}
+
+// toZapFields converts the log arguments to zap fields
+// the values tagged as secret are redacted
+func toZapFields(fields types.LogArg) []zap.Field {
 	zapFields := []zap.Field{}
 	for key, value := range fields {
-		zapFields = append(zapFields, zap.Any(key, value))
+		zapFields = append(zapFields, zap.Any(key, secrets.Redact(value)))
 	}
-	l.zapLog.Fatal(message, zapFields...)
+	return zapFields
 }
@@ -0,0 +1,300 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	backupTimeFormat = "20060102T150405.000"
+	compressSuffix   = ".gz"
+)
+
+// RotationInfo defines when the log file is rotated and how many
+// rotated files are kept. A zero value disables the rotation.
+type RotationInfo struct {
+	// MaxSize is the size in megabytes above which the file is rotated
+	MaxSize int
+	// MaxAge is the duration after which the file is rotated
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files to keep, 0 keeps all of them
+	MaxBackups int
+	// Compress the rotated files with gzip
+	Compress bool
+}
+
+func (r *RotationInfo) isEnabled() bool {
+	return r != nil && (r.MaxSize > 0 || r.MaxAge > 0)
+}
+
+// RotatingFile is a log file that is renamed with a timestamp
+// when it grows above a size or gets too old.
+// The rotated files are named after the log file: app.log is
+// rotated to app-20241208T101112.000.log
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation RotationInfo
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+
+	// compression and cleanup of the rotated files
+	// happens in the background, one at a time
+	backgroundMu sync.Mutex
+	background   sync.WaitGroup
+}
+
+// OpenRotatingFile opens the log file. If appendMode is false the content of
+// an existing file is rotated when the rotation is enabled, and discarded otherwise.
+func OpenRotatingFile(path string, appendMode bool, rotation *RotationInfo) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path: path,
+		now:  time.Now,
+	}
+	if rotation != nil {
+		f.rotation = *rotation
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %v", err)
+	}
+
+	if !appendMode {
+		info, err := os.Stat(path)
+		if err == nil && info.Size() > 0 && f.rotation.isEnabled() {
+			// keep the logs of the previous run
+			if err := f.backupFile(); err != nil {
+				return nil, err
+			}
+		} else if err == nil {
+			os.Remove(path)
+		}
+	}
+
+	if err := f.openFile(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Close closes the file and waits for the background compression to complete
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+
+	f.background.Wait()
+	return err
+}
+
+func (f *RotatingFile) shouldRotate(writeSize int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.rotation.MaxSize > 0 && f.size+int64(writeSize) > int64(f.rotation.MaxSize)*1024*1024 {
+		return true
+	}
+	if f.rotation.MaxAge > 0 && f.now().Sub(f.openedAt) >= f.rotation.MaxAge {
+		return true
+	}
+	return false
+}
+
+func (f *RotatingFile) openFile() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	if err := f.backupFile(); err != nil {
+		return err
+	}
+	return f.openFile()
+}
+
+// backupFile renames the log file and schedules the compression
+// and the removal of the old backups
+func (f *RotatingFile) backupFile() error {
+	backupPath := f.backupPath(f.now())
+	if err := os.Rename(f.path, backupPath); err != nil {
+		return fmt.Errorf("failed to rotate log file: %v", err)
+	}
+
+	f.background.Add(1)
+	go func() {
+		defer f.background.Done()
+		f.backgroundMu.Lock()
+		defer f.backgroundMu.Unlock()
+
+		if f.rotation.Compress {
+			// there is no logger to report that error to
+			_ = compressFile(backupPath)
+		}
+		f.removeOldBackups()
+	}()
+	return nil
+}
+
+// backupPath returns the name of the rotated file, a counter is added
+// when a file was already rotated at the same time, os.Rename would
+// overwrite it otherwise: app-20241208T101112.000-1.log
+func (f *RotatingFile) backupPath(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	base := fmt.Sprintf("%s-%s", strings.TrimSuffix(f.path, ext), t.Format(backupTimeFormat))
+	path := base + ext
+	for counter := 1; fileExists(path) || fileExists(path+compressSuffix); counter++ {
+		path = fmt.Sprintf("%s-%d%s", base, counter, ext)
+	}
+	return path
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// Backups returns the rotated files, from the oldest to the most recent
+func (f *RotatingFile) Backups() ([]string, error) {
+	dir := filepath.Dir(f.path)
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type backup struct {
+		path      string
+		timestamp string
+		counter   int
+	}
+	backups := []backup{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), compressSuffix)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		counter := 0
+		if before, after, found := strings.Cut(timestamp, "-"); found {
+			counter, err = strconv.Atoi(after)
+			if err != nil || counter <= 0 {
+				continue
+			}
+			timestamp = before
+		}
+		if _, err := time.Parse(backupTimeFormat, timestamp); err != nil {
+			continue
+		}
+		backups = append(backups, backup{filepath.Join(dir, entry.Name()), timestamp, counter})
+	}
+	// the timestamp format sorts chronologically
+	slices.SortFunc(backups, func(a, b backup) int {
+		if c := strings.Compare(a.timestamp, b.timestamp); c != 0 {
+			return c
+		}
+		return a.counter - b.counter
+	})
+	paths := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		paths = append(paths, backup.path)
+	}
+	return paths, nil
+}
+
+func (f *RotatingFile) removeOldBackups() {
+	if f.rotation.MaxBackups <= 0 {
+		return
+	}
+	backups, err := f.Backups()
+	if err != nil || len(backups) <= f.rotation.MaxBackups {
+		return
+	}
+	for _, backup := range backups[:len(backups)-f.rotation.MaxBackups] {
+		os.Remove(backup)
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + compressSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
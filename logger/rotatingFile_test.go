@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFileKeepsPreviousRun(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "mcp.log")
+	assert.NoError(t, os.WriteFile(logPath, []byte("crash\n"), 0644))
+
+	// append mode keeps the content
+	f, err := OpenRotatingFile(logPath, true, nil)
+	assert.NoError(t, err)
+	f.Write([]byte("restart\n"))
+	assert.NoError(t, f.Close())
+	content, _ := os.ReadFile(logPath)
+	assert.Equal(t, "crash\nrestart\n", string(content))
+
+	// without append mode, the previous content is rotated
+	f, err = OpenRotatingFile(logPath, false, &RotationInfo{MaxSize: 1})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	backups, err := f.Backups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	content, _ = os.ReadFile(backups[0])
+	assert.Equal(t, "crash\nrestart\n", string(content))
+}
+
+func TestRotatingFileRotationAndRetention(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "mcp.log")
+	now := time.Date(2024, 12, 8, 10, 0, 0, 0, time.UTC)
+
+	f, err := OpenRotatingFile(logPath, false, &RotationInfo{MaxAge: time.Hour, MaxBackups: 2, Compress: true})
+	assert.NoError(t, err)
+	f.now = func() time.Time { return now }
+	f.openedAt = now
+
+	for i := 0; i < 4; i++ {
+		_, err := f.Write([]byte("entry\n"))
+		assert.NoError(t, err)
+		now = now.Add(time.Hour)
+	}
+	_, err = f.Write([]byte("last\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	backups, err := f.Backups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for _, backup := range backups {
+		assert.True(t, strings.HasSuffix(backup, ".log.gz"), backup)
+	}
+	assert.True(t, strings.HasSuffix(backups[1], "mcp-20241208T140000.000.log.gz"), backups[1])
+
+	content, _ := os.ReadFile(logPath)
+	assert.Equal(t, "last\n", string(content))
+}
+
+func TestRotatingFileBackupsAtTheSameTime(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "mcp.log")
+	now := time.Date(2024, 12, 8, 10, 0, 0, 0, time.UTC)
+
+	f, err := OpenRotatingFile(logPath, false, &RotationInfo{MaxSize: 1})
+	assert.NoError(t, err)
+	f.now = func() time.Time { return now }
+
+	// the clock does not move, the rotated files must not overwrite each other
+	for _, entry := range []string{"first\n", "second\n", "third\n"} {
+		_, err := f.Write([]byte(entry))
+		assert.NoError(t, err)
+		f.mu.Lock()
+		assert.NoError(t, f.rotate())
+		f.mu.Unlock()
+	}
+	assert.NoError(t, f.Close())
+
+	backups, err := f.Backups()
+	assert.NoError(t, err)
+	if assert.Len(t, backups, 3) {
+		for i, entry := range []string{"first\n", "second\n", "third\n"} {
+			content, _ := os.ReadFile(backups[i])
+			assert.Equal(t, entry, string(content))
+		}
+		assert.True(t, strings.HasSuffix(backups[2], "mcp-20241208T100000.000-2.log"), backups[2])
+	}
+}
@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/llmcontext/gomcp/pkg/secrets"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// LevelFatal is the slog level used for the Fatal entries,
+// slog does not define any level above error
+const LevelFatal = slog.LevelError + 4
+
+// SlogLogger is a types.Logger writing to a slog.Logger
+type SlogLogger struct {
+	slogLogger *slog.Logger
+}
+
+func FromSlog(slogLogger *slog.Logger) types.Logger {
+	return &SlogLogger{slogLogger: slogLogger}
+}
+
+func (l *SlogLogger) Info(message string, fields types.LogArg) {
+	l.log(slog.LevelInfo, message, fields)
+}
+
+func (l *SlogLogger) Debug(message string, fields types.LogArg) {
+	l.log(slog.LevelDebug, message, fields)
+}
+
+func (l *SlogLogger) Error(message string, fields types.LogArg) {
+	l.log(slog.LevelError, message, fields)
+}
+
+// Fatal logs the message and exits the process, like the zap logger
+func (l *SlogLogger) Fatal(message string, fields types.LogArg) {
+	l.log(LevelFatal, message, fields)
+	os.Exit(1)
+}
+
+func (l *SlogLogger) log(level slog.Level, message string, fields types.LogArg) {
+	l.slogLogger.LogAttrs(context.Background(), level, message, toSlogAttrs(fields)...)
+}
+
+// toSlogAttrs converts the log arguments to slog attributes, sorted by key
+// the values tagged as secret are redacted
+func toSlogAttrs(fields types.LogArg) []slog.Attr {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, secrets.Redact(fields[key])))
+	}
+	return attrs
+}
+
+// SlogHandler is a slog.Handler writing to a types.Logger
+// debug records go to Debug, info and warn records to Info
+// and error records to Error. Fatal is never called.
+type SlogHandler struct {
+	logger types.Logger
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewSlogHandler returns a slog.Handler writing the records at or
+// above level to logger, all the records are written if level is nil
+func NewSlogHandler(logger types.Logger, level slog.Leveler) slog.Handler {
+	if level == nil {
+		level = slog.LevelDebug
+	}
+	return &SlogHandler{
+		logger: logger,
+		level:  level,
+	}
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := types.LogArg{}
+	for _, attr := range h.attrs {
+		addSlogAttr(fields, "", attr)
+	}
+	prefix := groupPrefix(h.groups)
+	record.Attrs(func(attr slog.Attr) bool {
+		addSlogAttr(fields, prefix, attr)
+		return true
+	})
+
+	switch {
+	case record.Level < slog.LevelInfo:
+		h.logger.Debug(record.Message, fields)
+	case record.Level < slog.LevelError:
+		h.logger.Info(record.Message, fields)
+	default:
+		h.logger.Error(record.Message, fields)
+	}
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	// the attributes are qualified by the current groups
+	prefix := groupPrefix(h.groups)
+	qualified := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		qualified = append(qualified, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+	}
+	return &SlogHandler{
+		logger: h.logger,
+		level:  h.level,
+		attrs:  append(slices.Clip(h.attrs), qualified...),
+		groups: h.groups,
+	}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{
+		logger: h.logger,
+		level:  h.level,
+		attrs:  h.attrs,
+		groups: append(slices.Clip(h.groups), name),
+	}
+}
+
+// addSlogAttr flattens the groups into dotted keys
+func addSlogAttr(fields types.LogArg, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range value.Group() {
+			addSlogAttr(fields, groupPrefix, groupAttr)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+	fields[prefix+attr.Key] = value.Any()
+}
+
+func groupPrefix(groups []string) string {
+	prefix := ""
+	for _, group := range groups {
+		prefix += group + "."
+	}
+	return prefix
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedEntry struct {
+	level   string
+	message string
+	fields  types.LogArg
+}
+
+type recordingLogger struct {
+	entries []recordedEntry
+}
+
+func (l *recordingLogger) Info(message string, fields types.LogArg) {
+	l.entries = append(l.entries, recordedEntry{"info", message, fields})
+}
+
+func (l *recordingLogger) Debug(message string, fields types.LogArg) {
+	l.entries = append(l.entries, recordedEntry{"debug", message, fields})
+}
+
+func (l *recordingLogger) Error(message string, fields types.LogArg) {
+	l.entries = append(l.entries, recordedEntry{"error", message, fields})
+}
+
+func (l *recordingLogger) Fatal(message string, fields types.LogArg) {
+	l.entries = append(l.entries, recordedEntry{"fatal", message, fields})
+}
+
+type tokenConfiguration struct {
+	Token string `gomcp:"secret"`
+}
+
+func TestFromSlog(t *testing.T) {
+	var buffer bytes.Buffer
+	slogLogger := slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	logger := FromSlog(slogLogger)
+	logger.Info("tool provider initialized", types.LogArg{
+		"provider": "notion",
+		"config":   &tokenConfiguration{Token: "ntn_123"},
+	})
+
+	output := buffer.String()
+	assert.True(t, strings.Contains(output, "level=INFO"))
+	assert.True(t, strings.Contains(output, `msg="tool provider initialized"`))
+	assert.True(t, strings.Contains(output, "provider=notion"))
+	assert.False(t, strings.Contains(output, "ntn_123"))
+}
+
+func TestSlogHandler(t *testing.T) {
+	recorder := &recordingLogger{}
+	slogLogger := slog.New(NewSlogHandler(recorder, slog.LevelInfo))
+
+	slogLogger.Debug("not logged")
+	slogLogger.With("tool", "ping").WithGroup("request").Warn("slow call", "duration", 3)
+	slogLogger.Error("failed", slog.Group("http", slog.Int("status", 500)))
+
+	assert.Equal(t, []recordedEntry{
+		{"info", "slow call", types.LogArg{"tool": "ping", "request.duration": int64(3)}},
+		{"error", "failed", types.LogArg{"http.status": int64(500)}},
+	}, recorder.entries)
+}
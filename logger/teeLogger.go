@@ -0,0 +1,37 @@
+package logger
+
+import "github.com/llmcontext/gomcp/types"
+
+// TeeLogger writes each log entry to all its loggers, in order
+type TeeLogger struct {
+	loggers []types.Logger
+}
+
+func NewTeeLogger(loggers ...types.Logger) types.Logger {
+	return &TeeLogger{loggers: loggers}
+}
+
+func (l *TeeLogger) Info(message string, fields types.LogArg) {
+	for _, logger := range l.loggers {
+		logger.Info(message, fields)
+	}
+}
+
+func (l *TeeLogger) Debug(message string, fields types.LogArg) {
+	for _, logger := range l.loggers {
+		logger.Debug(message, fields)
+	}
+}
+
+func (l *TeeLogger) Error(message string, fields types.LogArg) {
+	for _, logger := range l.loggers {
+		logger.Error(message, fields)
+	}
+}
+
+// Fatal may exit the process (zap does), put such a logger last
+func (l *TeeLogger) Fatal(message string, fields types.LogArg) {
+	for _, logger := range l.loggers {
+		logger.Fatal(message, fields)
+	}
+}
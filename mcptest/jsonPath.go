@@ -0,0 +1,93 @@
+package mcptest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is either an object key or an array index, "*" matches
+// all the elements of an array or all the keys of an object
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath parses a path like "result.content[0].text" or "result.tools[*].inputSchema"
+func parsePath(path string) ([]pathSegment, error) {
+	segments := []pathSegment{}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+		key := part
+		indexes := ""
+		if i := strings.Index(part, "["); i >= 0 {
+			key = part[:i]
+			indexes = part[i:]
+		}
+		if key != "" {
+			segments = append(segments, pathSegment{key: key})
+		}
+		for indexes != "" {
+			end := strings.Index(indexes, "]")
+			if !strings.HasPrefix(indexes, "[") || end < 0 {
+				return nil, fmt.Errorf("invalid path %q: malformed index", path)
+			}
+			index := indexes[1:end]
+			if index == "*" {
+				segments = append(segments, pathSegment{key: "*", isIndex: true})
+			} else {
+				n, err := strconv.Atoi(index)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path %q: index %s is not a number", path, index)
+				}
+				segments = append(segments, pathSegment{index: n, isIndex: true})
+			}
+			indexes = indexes[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+// removePath deletes the values matching the path from a decoded JSON value
+func removePath(value interface{}, segments []pathSegment) {
+	if len(segments) == 0 {
+		return
+	}
+	segment := segments[0]
+	last := len(segments) == 1
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if segment.isIndex && segment.key != "*" {
+			return
+		}
+		for key, child := range v {
+			if segment.key != "*" && segment.key != key {
+				continue
+			}
+			if last {
+				delete(v, key)
+			} else {
+				removePath(child, segments[1:])
+			}
+		}
+	case []interface{}:
+		if !segment.isIndex {
+			return
+		}
+		for i, child := range v {
+			if segment.key != "*" && segment.index != i {
+				continue
+			}
+			if last {
+				// keep the array length, the element is neutralized
+				v[i] = nil
+			} else {
+				removePath(child, segments[1:])
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Package mcptest provides utilities to test MCP servers
+package mcptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/trace"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+)
+
+const defaultReplayTimeout = 5 * time.Second
+
+type replayOptions struct {
+	ignorePaths []string
+	sessionId   string
+	timeout     time.Duration
+}
+
+type ReplayOption func(*replayOptions)
+
+// IgnorePaths excludes volatile fields from the comparison of the server
+// messages, eg. "result.serverInfo.version" or "result.content[*].text".
+// "*" matches all the elements of an array or all the keys of an object.
+func IgnorePaths(paths ...string) ReplayOption {
+	return func(o *replayOptions) {
+		o.ignorePaths = append(o.ignorePaths, paths...)
+	}
+}
+
+// WithSession replays the messages of a given session
+// by default, the first session of the transcript is replayed
+func WithSession(sessionId string) ReplayOption {
+	return func(o *replayOptions) {
+		o.sessionId = sessionId
+	}
+}
+
+// WithTimeout sets how long to wait for each message of the server
+func WithTimeout(timeout time.Duration) ReplayOption {
+	return func(o *replayOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Replay starts the server on an in-process transport, sends the client
+// messages of a recorded transcript and checks that the server messages
+// match the recorded ones, in the same order.
+func Replay(t testing.TB, server types.ModelContextProtocolServer, transcriptPath string, options ...ReplayOption) {
+	t.Helper()
+
+	opts := &replayOptions{timeout: defaultReplayTimeout}
+	for _, option := range options {
+		option(opts)
+	}
+
+	ignoredPaths := make([][]pathSegment, 0, len(opts.ignorePaths))
+	for _, path := range opts.ignorePaths {
+		segments, err := parsePath(path)
+		if err != nil {
+			t.Fatalf("mcptest: %v", err)
+		}
+		ignoredPaths = append(ignoredPaths, segments)
+	}
+
+	entries, err := trace.ReadTraceFile(transcriptPath)
+	if err != nil {
+		t.Fatalf("mcptest: failed to read transcript: %v", err)
+	}
+	entries = sessionEntries(entries, opts.sessionId)
+	if len(entries) == 0 {
+		t.Fatalf("mcptest: no message to replay in %s", transcriptPath)
+	}
+
+	inProcess := transport.NewInProcessTransport()
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.Start(inProcess)
+	}()
+	defer func() {
+		inProcess.Close()
+		select {
+		case <-stopped:
+		case <-time.After(opts.timeout):
+			t.Errorf("mcptest: server did not stop")
+		}
+	}()
+
+	for i, entry := range entries {
+		switch entry.Direction {
+		case trace.DirectionInbound:
+			message := entry.Message
+			if message == nil {
+				message = json.RawMessage(entry.Raw)
+			}
+			if err := inProcess.Deliver(message); err != nil {
+				t.Fatalf("mcptest: entry %d: failed to deliver message: %v", i+1, err)
+			}
+		case trace.DirectionOutbound:
+			select {
+			case actual := <-inProcess.Outgoing():
+				differences, err := diffMessages(entry.Message, actual, ignoredPaths)
+				if err != nil {
+					t.Fatalf("mcptest: entry %d: %v", i+1, err)
+				}
+				if len(differences) > 0 {
+					t.Errorf("mcptest: entry %d (%s) does not match:\n  %s\nexpected:\n%s\nactual:\n%s",
+						i+1, trace.Summary(entry), strings.Join(differences, "\n  "), indent(entry.Message), indent(actual))
+				}
+			case <-time.After(opts.timeout):
+				t.Fatalf("mcptest: entry %d: no message received from the server, expected %s", i+1, trace.Summary(entry))
+			}
+		}
+	}
+
+	// the server should not have anything else to say
+	select {
+	case extra := <-inProcess.Outgoing():
+		t.Errorf("mcptest: unexpected message from the server:\n%s", indent(extra))
+	default:
+	}
+}
+
+func sessionEntries(entries []*trace.Entry, sessionId string) []*trace.Entry {
+	if len(entries) == 0 {
+		return entries
+	}
+	if sessionId == "" {
+		sessionId = entries[0].SessionId
+	}
+	selected := []*trace.Entry{}
+	for _, entry := range entries {
+		if entry.SessionId == sessionId {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
+
+// diffMessages returns the list of differences between the two messages,
+// once the ignored paths are removed from both of them
+func diffMessages(expected json.RawMessage, actual json.RawMessage, ignoredPaths [][]pathSegment) ([]string, error) {
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal(expected, &expectedValue); err != nil {
+		return nil, fmt.Errorf("invalid recorded message: %v", err)
+	}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		return nil, fmt.Errorf("invalid server message: %v", err)
+	}
+	for _, segments := range ignoredPaths {
+		removePath(expectedValue, segments)
+		removePath(actualValue, segments)
+	}
+
+	differences := []string{}
+	diffValues("", expectedValue, actualValue, &differences)
+	return differences, nil
+}
+
+func diffValues(path string, expected interface{}, actual interface{}, differences *[]string) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(e)+len(a))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, found := e[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			expectedChild, inExpected := e[key]
+			actualChild, inActual := a[key]
+			switch {
+			case !inActual:
+				*differences = append(*differences, fmt.Sprintf("%s: missing, expected %s", childPath, compact(expectedChild)))
+			case !inExpected:
+				*differences = append(*differences, fmt.Sprintf("%s: unexpected %s", childPath, compact(actualChild)))
+			default:
+				diffValues(childPath, expectedChild, actualChild, differences)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		if len(e) != len(a) {
+			*differences = append(*differences, fmt.Sprintf("%s: expected %d elements, got %d", path, len(e), len(a)))
+			return
+		}
+		for i := range e {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), e[i], a[i], differences)
+		}
+		return
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		*differences = append(*differences, fmt.Sprintf("%s: expected %s, got %s", path, compact(expected), compact(actual)))
+	}
+}
+
+func compact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func indent(message json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(message, &value); err != nil {
+		return string(message)
+	}
+	data, err := json.MarshalIndent(value, "  ", "  ")
+	if err != nil {
+		return string(message)
+	}
+	return "  " + string(data)
+}
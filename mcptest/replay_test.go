@@ -0,0 +1,47 @@
+package mcptest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/mcptest"
+	"github.com/llmcontext/gomcp/types"
+)
+
+type pingConfiguration struct {
+	Name string
+}
+
+type pingContext struct {
+	Name string
+}
+
+func pingInit(ctx context.Context, config *pingConfiguration) (*pingContext, error) {
+	return &pingContext{Name: config.Name}, nil
+}
+
+type pingInput struct {
+	Message string `json:"message" jsonschema_description:"the message to ping."`
+}
+
+func ping(ctx context.Context, toolCtx *pingContext, input *pingInput, output types.ToolCallResult) error {
+	output.AddTextContent(fmt.Sprintf("pong %s from %s", input.Message, toolCtx.Name))
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("dummy", "0.0.2")
+	tools := definition.WithTools(&pingConfiguration{Name: "dummy"}, pingInit)
+	tools.AddTool("ping", "A ping function", ping)
+
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the transcript was recorded with version 0.0.1
+	mcptest.Replay(t, server, "testdata/ping.jsonl",
+		mcptest.IgnorePaths("result.serverInfo.version"))
+}
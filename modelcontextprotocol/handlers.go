@@ -10,10 +10,13 @@ import (
 
 type McpServerEventHandler interface {
 	// tools
-	ExecuteToolsList(ctx context.Context, logger types.Logger) (*mcp.JsonRpcResponseToolsListResult, *jsonrpc.JsonRpcError)
+	ExecuteToolsList(ctx context.Context, params *mcp.JsonRpcRequestToolsListParams, logger types.Logger) (*mcp.JsonRpcResponseToolsListResult, *jsonrpc.JsonRpcError)
 	ExecuteToolCall(ctx context.Context, params *mcp.JsonRpcRequestToolsCallParams, logger types.Logger) (types.ToolCallResult, *jsonrpc.JsonRpcError)
 
 	// prompts
-	ExecutePromptsList(ctx context.Context, logger types.Logger) (*mcp.JsonRpcResponsePromptsListResult, *jsonrpc.JsonRpcError)
+	ExecutePromptsList(ctx context.Context, params *mcp.JsonRpcRequestPromptsListParams, logger types.Logger) (*mcp.JsonRpcResponsePromptsListResult, *jsonrpc.JsonRpcError)
 	ExecutePromptGet(ctx context.Context, params *mcp.JsonRpcRequestPromptsGetParams, logger types.Logger) (types.PromptGetResult, *jsonrpc.JsonRpcError)
+
+	// completion
+	ExecuteCompletion(ctx context.Context, params *mcp.JsonRpcRequestCompletionCompleteParams, logger types.Logger) (*mcp.JsonRpcResponseCompletionCompleteResult, *jsonrpc.JsonRpcError)
 }
@@ -0,0 +1,317 @@
+// Package mcpclient connects to an MCP server, eg. a server started
+// with transport.NewCommandTransport, and calls its tools, prompts
+// and resources
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// ServerError is returned when the server answers a request with an error
+type ServerError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("%s failed: %s (code %d)", e.Method, e.Message, e.Code)
+}
+
+type McpClient struct {
+	clientName    string
+	clientVersion string
+	logger        types.Logger
+
+	jsonRpcTransport *transport.JsonRpcTransport
+	serverInfo       *mcp.JsonRpcResponseInitializeResult
+	onNotification   func(method string, params *jsonrpc.JsonRpcParams)
+
+	// closed when the transport stops, doneErr is the
+	// error returned by the transport
+	done    chan struct{}
+	doneErr error
+}
+
+func NewMcpClient(clientName string, clientVersion string, logger types.Logger) *McpClient {
+	return &McpClient{
+		clientName:    clientName,
+		clientVersion: clientVersion,
+		logger:        logger,
+		done:          make(chan struct{}),
+	}
+}
+
+// OnNotification is called for each notification sent by the server,
+// eg. notifications/message. It must be set before Connect.
+func (c *McpClient) OnNotification(callback func(method string, params *jsonrpc.JsonRpcParams)) {
+	c.onNotification = callback
+}
+
+// Connect starts the transport and runs the initialization handshake
+func (c *McpClient) Connect(ctx context.Context, tran types.Transport) (*mcp.JsonRpcResponseInitializeResult, error) {
+	c.jsonRpcTransport = transport.NewJsonRpcTransport(tran, "mcp client", c.logger)
+	started := make(chan struct{})
+	c.jsonRpcTransport.OnStarted(func() {
+		close(started)
+	})
+
+	go func() {
+		// the transport must keep running after Connect returns
+		err := c.jsonRpcTransport.Start(context.Background(), func(message transport.JsonRpcMessage, _ *transport.JsonRpcTransport) {
+			c.handleIncomingMessage(message)
+		})
+		// doneErr is read once done is closed
+		c.doneErr = err
+		close(c.done)
+	}()
+
+	// the messages can't be sent before the transport is started
+	select {
+	case <-started:
+	case <-c.done:
+		return nil, fmt.Errorf("failed to start transport: %v", c.doneErr)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// we don't use JsonRpcRequestInitializeParams: the client
+	// must not advertise roots or sampling, it supports neither
+	response, err := c.request(ctx, mcp.RpcRequestMethodInitialize, map[string]interface{}{
+		"protocolVersion": mcp.ProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": mcp.ClientInfo{
+			Name:    c.clientName,
+			Version: c.clientVersion,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponseInitialize(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid initialize response: %v", err)
+	}
+	// the server answers with the version it picked, which
+	// may be older than the one requested
+	if !mcp.IsSupportedProtocolVersion(result.ProtocolVersion) {
+		return nil, fmt.Errorf("unsupported protocol version: %s", result.ProtocolVersion)
+	}
+	c.serverInfo = result
+
+	// the server is now ready to receive requests
+	c.jsonRpcTransport.SendNotification(mcp.RpcNotificationMethodInitialized)
+
+	return result, nil
+}
+
+// ServerInfo returns the result of the initialization, nil before Connect
+func (c *McpClient) ServerInfo() *mcp.JsonRpcResponseInitializeResult {
+	return c.serverInfo
+}
+
+func (c *McpClient) ListTools(ctx context.Context) (*mcp.JsonRpcResponseToolsListResult, error) {
+	result := &mcp.JsonRpcResponseToolsListResult{
+		Tools: []mcp.ToolDescription{},
+	}
+	params := &mcp.JsonRpcRequestToolsListParams{}
+	for {
+		response, err := c.request(ctx, mcp.RpcRequestMethodToolsList, params)
+		if err != nil {
+			return nil, err
+		}
+		page, err := mcp.ParseJsonRpcResponseToolsList(response)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tools/list response: %v", err)
+		}
+		result.Tools = append(result.Tools, page.Tools...)
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			return result, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+func (c *McpClient) CallTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*mcp.JsonRpcResponseToolsCallResult, error) {
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+	response, err := c.request(ctx, mcp.RpcRequestMethodToolsCall, &mcp.JsonRpcRequestToolsCallParams{
+		Name:      toolName,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponseToolsCall(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tools/call response: %v", err)
+	}
+	return result, nil
+}
+
+func (c *McpClient) ListPrompts(ctx context.Context) (*mcp.JsonRpcResponsePromptsListResult, error) {
+	result := &mcp.JsonRpcResponsePromptsListResult{
+		Prompts: []mcp.PromptDescription{},
+	}
+	params := &mcp.JsonRpcRequestPromptsListParams{}
+	for {
+		response, err := c.request(ctx, mcp.RpcRequestMethodPromptsList, params)
+		if err != nil {
+			return nil, err
+		}
+		page, err := mcp.ParseJsonRpcResponsePromptsList(response)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompts/list response: %v", err)
+		}
+		result.Prompts = append(result.Prompts, page.Prompts...)
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			return result, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+func (c *McpClient) GetPrompt(ctx context.Context, promptName string, arguments map[string]string) (*mcp.JsonRpcResponsePromptsGetResult, error) {
+	if arguments == nil {
+		arguments = map[string]string{}
+	}
+	response, err := c.request(ctx, mcp.RpcRequestMethodPromptsGet, &mcp.JsonRpcRequestPromptsGetParams{
+		Name:      promptName,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponsePromptsGet(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompts/get response: %v", err)
+	}
+	return result, nil
+}
+
+// Complete asks the server the values of an argument of a prompt or of a
+// resource template, ref is built with mcp.CompletionRefPrompt or mcp.CompletionRefResource
+func (c *McpClient) Complete(ctx context.Context, ref mcp.CompletionReference, argumentName string, value string) (*mcp.JsonRpcResponseCompletionCompleteResult, error) {
+	response, err := c.request(ctx, mcp.RpcRequestMethodCompletionComplete, &mcp.JsonRpcRequestCompletionCompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompletionArgument{Name: argumentName, Value: value},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponseCompletionComplete(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid completion/complete response: %v", err)
+	}
+	return result, nil
+}
+
+func (c *McpClient) ListResources(ctx context.Context) (*mcp.JsonRpcResponseResourcesListResult, error) {
+	result := &mcp.JsonRpcResponseResourcesListResult{
+		Resources: []mcp.ResourceDescription{},
+	}
+	params := &mcp.JsonRpcRequestResourcesListParams{}
+	for {
+		response, err := c.request(ctx, mcp.RpcRequestMethodResourcesList, params)
+		if err != nil {
+			return nil, err
+		}
+		page, err := mcp.ParseJsonRpcResponseResourcesList(response)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resources/list response: %v", err)
+		}
+		result.Resources = append(result.Resources, page.Resources...)
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			return result, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+func (c *McpClient) ReadResource(ctx context.Context, uri string) (*mcp.JsonRpcResponseResourcesReadResult, error) {
+	response, err := c.request(ctx, mcp.RpcRequestMethodResourcesRead, &mcp.JsonRpcRequestResourcesReadParams{
+		Uri: uri,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponseResourcesRead(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resources/read response: %v", err)
+	}
+	return result, nil
+}
+
+// Ping checks that the server is still responding
+func (c *McpClient) Ping(ctx context.Context) error {
+	_, err := c.request(ctx, "ping", struct{}{})
+	return err
+}
+
+// Close stops the transport, which stops a server started by a CommandTransport
+func (c *McpClient) Close() {
+	if c.jsonRpcTransport != nil {
+		c.jsonRpcTransport.Close()
+		<-c.done
+	}
+}
+
+// request sends a request and waits for its response
+func (c *McpClient) request(ctx context.Context, method string, params interface{}) (*jsonrpc.JsonRpcResponse, error) {
+	response, err := c.jsonRpcTransport.Call(ctx, method, params)
+	if errors.Is(err, transport.ErrTransportClosed) {
+		<-c.done
+		return nil, fmt.Errorf("%s: connection closed: %v", method, c.doneErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, &ServerError{
+			Method:  method,
+			Code:    response.Error.Code,
+			Message: response.Error.Message,
+		}
+	}
+	return response, nil
+}
+
+func (c *McpClient) handleIncomingMessage(message transport.JsonRpcMessage) {
+	// the responses to the requests are read by Call
+	if message.Response != nil {
+		c.logger.Error("response to an unknown request", types.LogArg{
+			"id":     jsonrpc.RequestIdToString(message.Response.Id),
+			"method": message.Method,
+		})
+		return
+	}
+
+	request := message.Request
+	if request == nil {
+		return
+	}
+
+	// notifications have no id
+	if request.Id == nil {
+		if c.onNotification != nil {
+			c.onNotification(request.Method, request.Params)
+		}
+		return
+	}
+
+	switch request.Method {
+	case "ping":
+		c.jsonRpcTransport.SendJsonRpcResponse(json.RawMessage(`{}`), request.Id)
+	default:
+		c.jsonRpcTransport.SendError(jsonrpc.RpcMethodNotFound, fmt.Sprintf("unknown method: %s", request.Method), request.Id)
+	}
+}
@@ -0,0 +1,155 @@
+package mcpclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/modelcontextprotocol/mcpclient"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientTransport is the client side of an in-process transport
+type clientTransport struct {
+	server    *transport.InProcessTransport
+	onStarted func()
+	onMessage func(json.RawMessage)
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (t *clientTransport) Start(ctx context.Context) error {
+	if t.onStarted != nil {
+		t.onStarted()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.done:
+			return fmt.Errorf("closed")
+		case message := <-t.server.Outgoing():
+			t.onMessage(message)
+		}
+	}
+}
+
+func (t *clientTransport) Send(message json.RawMessage) error {
+	return t.server.Deliver(message)
+}
+
+func (t *clientTransport) Close() {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.server.Close()
+	})
+}
+
+func (t *clientTransport) OnStarted(callback func())                { t.onStarted = callback }
+func (t *clientTransport) OnMessage(callback func(json.RawMessage)) { t.onMessage = callback }
+func (t *clientTransport) OnClose(callback func())                  {}
+func (t *clientTransport) OnError(callback func(error))             {}
+
+type pingConfiguration struct {
+	Name string
+}
+
+type pingContext struct {
+	Name string
+}
+
+func pingInit(ctx context.Context, config *pingConfiguration) (*pingContext, error) {
+	return &pingContext{Name: config.Name}, nil
+}
+
+type pingInput struct {
+	Message string `json:"message" jsonschema_description:"the message to ping."`
+}
+
+func ping(ctx context.Context, toolCtx *pingContext, input *pingInput, output types.ToolCallResult) error {
+	if input.Message == "" {
+		output.SetError(true)
+	}
+	output.AddTextContent(fmt.Sprintf("pong %s from %s", input.Message, toolCtx.Name))
+	return nil
+}
+
+func TestClient(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("dummy", "0.0.1")
+	tools := definition.WithTools(&pingConfiguration{Name: "dummy"}, pingInit)
+	tools.AddTool("ping", "A ping function", ping)
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	assert.NoError(t, err)
+
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := mcpclient.NewMcpClient("test", "1.0", logger.FromSlog(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	serverInfo, err := client.Connect(ctx, &clientTransport{server: serverTransport, done: make(chan struct{})})
+	assert.NoError(t, err)
+	defer client.Close()
+	assert.Equal(t, "dummy", serverInfo.ServerInfo.Name)
+
+	toolsList, err := client.ListTools(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, toolsList.Tools, 1)
+	assert.Equal(t, "ping", toolsList.Tools[0].Name)
+
+	result, err := client.CallTool(ctx, "ping", map[string]interface{}{"message": "hello"})
+	assert.NoError(t, err)
+	assert.Nil(t, result.IsError)
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "text", "text": "pong hello from dummy"}}, result.Content)
+
+	result, err = client.CallTool(ctx, "ping", map[string]interface{}{"message": ""})
+	assert.NoError(t, err)
+	assert.True(t, *result.IsError)
+
+	_, err = client.ReadResource(ctx, "file:///unknown")
+	var serverError *mcpclient.ServerError
+	assert.ErrorAs(t, err, &serverError)
+	assert.Equal(t, "resources/read", serverError.Method)
+
+	assert.NoError(t, client.Ping(ctx))
+}
+
+func TestClientFollowsCursors(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("dummy", "0.0.1")
+	definition.SetListPageSize(2)
+	tools := definition.WithTools(&pingConfiguration{Name: "dummy"}, pingInit)
+	for _, name := range []string{"ping1", "ping2", "ping3", "ping4", "ping5"} {
+		tools.AddTool(name, "A ping function", ping)
+	}
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	assert.NoError(t, err)
+
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := mcpclient.NewMcpClient("test", "1.0", logger.FromSlog(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	_, err = client.Connect(ctx, &clientTransport{server: serverTransport, done: make(chan struct{})})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	toolsList, err := client.ListTools(ctx)
+	assert.NoError(t, err)
+	names := []string{}
+	for _, tool := range toolsList.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"ping1", "ping2", "ping3", "ping4", "ping5"}, names)
+}
@@ -0,0 +1,75 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	invopop "github.com/invopop/jsonschema"
+	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+func (s *clientSession) Elicit(ctx context.Context, message string, content interface{}) (types.ElicitAction, error) {
+	if !s.capabilities.Elicitation || !s.features.Elicitation {
+		return "", types.ErrElicitationNotSupported
+	}
+
+	contentType := reflect.TypeOf(content)
+	if contentType == nil || contentType.Kind() != reflect.Ptr || contentType.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("the content must be a pointer to a struct")
+	}
+	schema, typeName, err := jsonschema.GetSchemaFromType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("error generating schema for the content: %v", err)
+	}
+	if err := checkElicitationSchema(schema); err != nil {
+		return "", fmt.Errorf("invalid content %s: %v", typeName, err)
+	}
+
+	response, err := s.request(ctx, mcp.RpcRequestMethodElicitationCreate, &mcp.JsonRpcRequestElicitationCreateParams{
+		Message:         message,
+		RequestedSchema: schema,
+	})
+	if err != nil {
+		return "", err
+	}
+	result, err := mcp.ParseJsonRpcResponseElicitationCreate(response)
+	if err != nil {
+		return "", fmt.Errorf("invalid elicitation/create response: %v", err)
+	}
+	if result.Action != mcp.ElicitationActionAccept {
+		return types.ElicitAction(result.Action), nil
+	}
+
+	// the client is expected to check the values, but we can't rely on it
+	if err := jsonschema.ValidateJsonSchemaWithObject(schema, result.Content); err != nil {
+		return "", fmt.Errorf("invalid elicitation content: %v", err)
+	}
+	data, err := json.Marshal(result.Content)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(data, content); err != nil {
+		return "", fmt.Errorf("invalid elicitation content: %v", err)
+	}
+	return types.ElicitActionAccept, nil
+}
+
+// checkElicitationSchema checks that the schema is a flat object: the
+// clients only support the primitive types in their forms
+func checkElicitationSchema(schema *invopop.Schema) error {
+	if schema.Properties == nil {
+		return nil
+	}
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		switch pair.Value.Type {
+		case "string", "number", "integer", "boolean":
+		default:
+			return fmt.Errorf("field %s: only strings, numbers and booleans can be requested", pair.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type deployInput struct {
+	Service string `json:"service"`
+}
+
+type deployConfirmation struct {
+	Environment string `json:"environment" jsonschema:"enum=staging,enum=production"`
+	Replicas    int    `json:"replicas,omitempty"`
+}
+
+type deployNested struct {
+	Target deployConfirmation `json:"target"`
+}
+
+func startDeployer(t *testing.T, protocolVersion string, capabilities string) *transport.InProcessTransport {
+	definition := gomcp.NewMcpServerDefinition("deployer", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("deploy", "Deploys a service", func(ctx context.Context, toolCtx *speechContext, input *deployInput, output types.ToolCallResult) error {
+		confirmation := deployConfirmation{}
+		action, err := types.Elicit(ctx, "Where should "+input.Service+" be deployed?", &confirmation)
+		if err != nil {
+			output.AddTextContent(err.Error())
+			return nil
+		}
+		output.AddTextContent(fmt.Sprintf("%s %s %d", action, confirmation.Environment, confirmation.Replicas))
+		return nil
+	})
+	tools.AddTool("deploy_nested", "Deploys a service", func(ctx context.Context, toolCtx *speechContext, input *deployInput, output types.ToolCallResult) error {
+		_, err := types.Elicit(ctx, "Where?", &deployNested{})
+		output.AddTextContent(err.Error())
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+
+	exchange(t, serverTransport, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":%q,"capabilities":%s,"clientInfo":{"name":"test","version":"1.0"}}}`, protocolVersion, capabilities))
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	return serverTransport
+}
+
+// answerElicitation answers the next elicitation/create request and
+// returns the text of the tool result
+func answerElicitation(t *testing.T, serverTransport *transport.InProcessTransport, result string) string {
+	request := nextRequest(t, serverTransport)
+	assert.Equal(t, "elicitation/create", request["method"])
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result":  json.RawMessage(result),
+	})
+	assert.NoError(t, err)
+	toolResult := exchange(t, serverTransport, string(response))
+	return toolResult["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+}
+
+func TestElicitation(t *testing.T) {
+	serverTransport := startDeployer(t, "2025-06-18", `{"elicitation":{}}`)
+	defer serverTransport.Close()
+
+	callDeploy := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"deploy","arguments":{"service":"api"}}}`
+
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(callDeploy)))
+	request := nextRequest(t, serverTransport)
+	params := request["params"].(map[string]interface{})
+	assert.Equal(t, "Where should api be deployed?", params["message"])
+	schema := params["requestedSchema"].(map[string]interface{})
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []interface{}{"environment"}, schema["required"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"staging", "production"}}, schema["properties"].(map[string]interface{})["environment"])
+
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result":  map[string]interface{}{"action": "accept", "content": map[string]interface{}{"environment": "staging", "replicas": 3}},
+	})
+	assert.NoError(t, err)
+	result := exchange(t, serverTransport, string(response))
+	assert.Equal(t, "accept staging 3", result["content"].([]interface{})[0].(map[string]interface{})["text"])
+
+	// the content is not decoded if the user does not accept
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(callDeploy)))
+	assert.Equal(t, "decline  0", answerElicitation(t, serverTransport, `{"action":"decline"}`))
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(callDeploy)))
+	assert.Equal(t, "cancel  0", answerElicitation(t, serverTransport, `{"action":"cancel"}`))
+
+	// the content must match the schema
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(callDeploy)))
+	assert.Contains(t, answerElicitation(t, serverTransport, `{"action":"accept","content":{"environment":"qa"}}`), "invalid elicitation content")
+}
+
+func TestElicitationNestedContent(t *testing.T) {
+	serverTransport := startDeployer(t, "2025-06-18", `{"elicitation":{}}`)
+	defer serverTransport.Close()
+
+	result := exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"deploy_nested","arguments":{"service":"api"}}}`)
+	assert.Equal(t, "invalid content deployNested: field target: only strings, numbers and booleans can be requested", result["content"].([]interface{})[0].(map[string]interface{})["text"])
+}
+
+func TestElicitationNotSupported(t *testing.T) {
+	// the capability is ignored with the versions not supporting elicitation
+	for _, test := range []struct{ protocolVersion, capabilities string }{
+		{"2025-06-18", `{}`},
+		{"2025-03-26", `{"elicitation":{}}`},
+	} {
+		serverTransport := startDeployer(t, test.protocolVersion, test.capabilities)
+		result := exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"deploy","arguments":{"service":"api"}}}`)
+		assert.Equal(t, types.ErrElicitationNotSupported.Error(), result["content"].([]interface{})[0].(map[string]interface{})["text"])
+		serverTransport.Close()
+	}
+}
+
+func TestElicitationFromPrompt(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("deployer", "0.0.1")
+	definition.WithTools(nil, speechInit)
+	assert.NoError(t, definition.AddPrompt("deploy_plan", "Plans a deployment", func(ctx context.Context, toolCtx *speechContext, input *deployInput, output types.PromptGetResult) error {
+		// the response of the client is read while the prompt handler waits for it
+		confirmation := deployConfirmation{}
+		action, err := types.Elicit(ctx, "Where should "+input.Service+" be deployed?", &confirmation)
+		if err != nil {
+			return err
+		}
+		output.AddTextContent(types.RoleUser, fmt.Sprintf("Plan the %s of %s to %s", action, input.Service, confirmation.Environment))
+		return nil
+	}))
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{"elicitation":{}},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"prompts/get","params":{"name":"deploy_plan","arguments":{"service":"api"}}}`)))
+	request := nextRequest(t, serverTransport)
+	assert.Equal(t, "elicitation/create", request["method"])
+
+	// the server keeps answering while the prompt handler waits
+	assert.Equal(t, map[string]interface{}{}, exchange(t, serverTransport, `{"jsonrpc":"2.0","id":3,"method":"ping"}`))
+
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result":  map[string]interface{}{"action": "accept", "content": map[string]interface{}{"environment": "staging"}},
+	})
+	assert.NoError(t, err)
+	result := exchange(t, serverTransport, string(response))
+	message := result["messages"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Plan the accept of api to staging", message["content"].(map[string]interface{})["text"])
+}
@@ -0,0 +1,36 @@
+package mcpserver
+
+import (
+	"encoding/json"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+func newClientLogger(m *McpServer) *logger.ClientLogger {
+	return logger.NewClientLogger(m.serverName, func(params *mcp.JsonRpcNotificationMessageParams) error {
+		// the client is not ready to receive notifications yet, the
+		// transport is set before the client is initialized
+		if !m.isClientInitialized.Load() {
+			return nil
+		}
+		return m.jsonRpcTransport.SendNotificationWithParams(mcp.RpcNotificationMethodMessage, params)
+	})
+}
+
+// handlerLogger returns the logger passed to the tools and prompts handlers,
+// the entries are written to the log file and forwarded to the client
+func (m *McpServer) handlerLogger(name string) types.Logger {
+	return logger.NewTeeLogger(m.clientLogger.WithName(name), m.logger)
+}
+
+func (m *McpServer) EventMcpRequestLoggingSetLevel(params *mcp.JsonRpcRequestLoggingSetLevelParams, reqId *jsonrpc.JsonRpcRequestId) {
+	m.logger.Info("client logging level changed", types.LogArg{
+		"level": params.Level,
+	})
+	m.clientLogger.SetLevel(params.Level)
+	result := json.RawMessage(`{}`)
+	m.jsonRpcTransport.SendJsonRpcResponse(result, reqId)
+}
@@ -0,0 +1,63 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func speakWithLog(ctx context.Context, toolCtx *speechContext, input *speechInput, output types.ToolCallResult) error {
+	types.GetLogger(ctx).Info("speaking", types.LogArg{"text": input.Text})
+	output.AddTextContent(input.Text)
+	return nil
+}
+
+func TestLoggingSetLevel(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("speech", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("speak", "Reads a text", speakWithLog)
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	result := exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.Equal(t, map[string]interface{}{}, result["capabilities"].(map[string]interface{})["logging"])
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	// nothing is forwarded before the client sets a level
+	call := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"speak","arguments":{"text":"hello"}}}`
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(call)))
+	assert.NotContains(t, string(nextMessage(t, serverTransport)), "notifications/message")
+
+	assert.Equal(t, map[string]interface{}{}, exchange(t, serverTransport, `{"jsonrpc":"2.0","id":3,"method":"logging/setLevel","params":{"level":"info"}}`))
+
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(call)))
+	notification := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(nextMessage(t, serverTransport), &notification))
+	assert.Equal(t, "notifications/message", notification["method"])
+	assert.Equal(t, map[string]interface{}{
+		"level":  "info",
+		"logger": "speak",
+		"data":   map[string]interface{}{"message": "speaking", "text": "hello"},
+	}, notification["params"])
+}
+
+func nextMessage(t *testing.T, serverTransport *transport.InProcessTransport) json.RawMessage {
+	select {
+	case message := <-serverTransport.Outgoing():
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message from the server")
+		return nil
+	}
+}
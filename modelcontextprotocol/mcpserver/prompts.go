@@ -0,0 +1,32 @@
+package mcpserver
+
+import (
+	"github.com/llmcontext/gomcp/pkg/prompts"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// onPromptsReload is called each time the watched prompts files are reloaded
+func (m *McpServer) onPromptsReload(duplicatedPrompts []*prompts.DuplicatedPrompt, err error) {
+	if err != nil {
+		m.logger.Error("failed to reload the prompts, the previous version is kept", types.LogArg{
+			"error": err,
+		})
+		return
+	}
+	// the first definition is kept, like when the files were loaded
+	for _, duplicatedPrompt := range duplicatedPrompts {
+		m.logger.Error("duplicated prompt ignored", types.LogArg{
+			"prompt": duplicatedPrompt.PromptName,
+			"file":   duplicatedPrompt.FilePath,
+		})
+	}
+	m.logger.Info("prompts reloaded", types.LogArg{})
+
+	// the client is not ready to receive notifications yet, the
+	// transport is set before the client is initialized
+	if !m.isClientInitialized.Load() {
+		return
+	}
+	m.jsonRpcTransport.SendNotification(mcp.RpcNotificationMethodPromptsListChanged)
+}
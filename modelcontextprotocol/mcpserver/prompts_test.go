@@ -0,0 +1,50 @@
+package mcpserver_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptsListChanged(t *testing.T) {
+	promptsFile := filepath.Join(t.TempDir(), "prompts.yaml")
+	assert.NoError(t, os.WriteFile(promptsFile, []byte("prompts:\n  - name: first\n    description: a watched prompt\n    prompt: hello\n"), 0o644))
+
+	definition := gomcp.NewMcpServerDefinition("watcher", "0.0.1")
+	_, err := definition.AddTemplateYamlFile(promptsFile)
+	assert.NoError(t, err)
+	definition.WatchPromptFiles(10 * time.Millisecond)
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	// the watcher goroutine notifies the client, run with -race
+	// to check the accesses to the state of the session
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(promptsFile, []byte("prompts:\n  - name: second\n    description: a watched prompt\n    prompt: hello\n"), 0o644))
+	for {
+		select {
+		case message := <-serverTransport.Outgoing():
+			notification := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(message, &notification))
+			if notification["method"] == "notifications/prompts/list_changed" {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no prompts/list_changed notification")
+		}
+	}
+}
@@ -4,14 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/pkg/pagination"
 	"github.com/llmcontext/gomcp/protocol/mcp"
 	"github.com/llmcontext/gomcp/transport"
 	"github.com/llmcontext/gomcp/types"
 )
 
+// featureGatedResult is a result that can contain features
+// not supported by older versions of the protocol
+type featureGatedResult interface {
+	ApplyProtocolFeatures(features mcp.ProtocolFeatures)
+}
+
 func (m *McpServer) startProtocol(ctx context.Context, tran types.Transport) error {
+	// the trace file and the inspector share the same session id
+	sessionId := transport.NewSessionId()
+
+	// record the messages if requested
+	if m.protocolDebugFile != "" {
+		recorder, err := transport.NewRecordingTransport(tran, m.protocolDebugFile)
+		if err != nil {
+			m.logger.Error("failed to record protocol messages", types.LogArg{
+				"file":  m.protocolDebugFile,
+				"error": err,
+			})
+		} else {
+			m.logger.Info("recording protocol messages", types.LogArg{
+				"file":      m.protocolDebugFile,
+				"sessionId": recorder.SessionId(),
+			})
+			sessionId = recorder.SessionId()
+			tran = recorder
+		}
+	}
+
+	// show the messages in the inspector
+	if m.inspector != nil {
+		tran = transport.NewRecordingTransportWithWriter(tran, m.inspector, sessionId)
+	}
+
 	// create a new json rpc transport
 	jsonRpcTransport := transport.NewJsonRpcTransport(tran, "mcp server", m.logger)
 	m.jsonRpcTransport = jsonRpcTransport
@@ -65,6 +99,8 @@ func (m *McpServer) handleIncomingMessage(
 			return nil
 		}
 		switch message.Method {
+		case mcp.RpcRequestMethodRootsList:
+			m.EventMcpResponseRootsList(response)
 		default:
 			m.logger.Error("received message with unexpected method", types.LogArg{
 				"method": message.Method,
@@ -73,22 +109,33 @@ func (m *McpServer) handleIncomingMessage(
 		}
 	} else if message.Request != nil {
 		request := message.Request
+		// each request must get a response, eg. in a batch the
+		// responses are only sent once all the requests are answered
+		if request.Id != nil && strings.HasPrefix(request.Method, "notifications/") {
+			m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, fmt.Sprintf("%s is a notification, it must not have an id", request.Method), request.Id)
+			return nil
+		}
 		switch message.Method {
 		case mcp.RpcRequestMethodInitialize:
 			{
 				parsed, err := mcp.ParseJsonRpcRequestInitialize(request)
 				if err != nil {
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
+					return nil
 				}
 				m.EventMcpRequestInitialize(parsed, request.Id)
 			}
 		case mcp.RpcNotificationMethodInitialized:
 			m.EventMcpNotificationInitialized()
+		case mcp.RpcNotificationMethodRootsListChanged:
+			// the cached roots are replaced by the response
+			m.requestRoots()
 		case mcp.RpcRequestMethodToolsList:
 			{
 				parsed, err := mcp.ParseJsonRpcRequestToolsList(request)
 				if err != nil {
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
+					return nil
 				}
 				m.EventMcpRequestToolsList(ctx, parsed, request.Id)
 			}
@@ -99,13 +146,16 @@ func (m *McpServer) handleIncomingMessage(
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
 					return nil
 				}
-				m.EventMcpRequestToolsCall(ctx, parsed, request.Id)
+				// the tool may send requests to the client, eg. for sampling, their
+				// responses are read while the tool is running
+				go m.EventMcpRequestToolsCall(ctx, m.session.Load(), parsed, request.Id)
 			}
 		case mcp.RpcRequestMethodResourcesList:
 			{
 				parsed, err := mcp.ParseJsonRpcRequestResourcesList(request.Params)
 				if err != nil {
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
+					return nil
 				}
 				m.EventMcpRequestResourcesList(parsed, request.Id)
 			}
@@ -114,6 +164,7 @@ func (m *McpServer) handleIncomingMessage(
 				parsed, err := mcp.ParseJsonRpcRequestPromptsList(request.Params)
 				if err != nil {
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
+					return nil
 				}
 				m.EventMcpRequestPromptsList(ctx, parsed, request.Id)
 			}
@@ -122,13 +173,40 @@ func (m *McpServer) handleIncomingMessage(
 				parsed, err := mcp.ParseJsonRpcRequestPromptsGet(request.Params)
 				if err != nil {
 					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, err.Error(), request.Id)
+					return nil
+				}
+				// like the tools, the prompt handlers may send requests to the client
+				go m.EventMcpRequestPromptsGet(ctx, m.session.Load(), parsed, request.Id)
+			}
+		case mcp.RpcRequestMethodCompletionComplete:
+			{
+				parsed, err := mcp.ParseJsonRpcRequestCompletionComplete(request.Params)
+				if err != nil {
+					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidParams, err.Error(), request.Id)
+					return nil
 				}
-				m.EventMcpRequestPromptsGet(ctx, parsed, request.Id)
+				go m.EventMcpRequestCompletionComplete(ctx, m.session.Load(), parsed, request.Id)
+			}
+		case mcp.RpcRequestMethodLoggingSetLevel:
+			{
+				parsed, err := mcp.ParseJsonRpcRequestLoggingSetLevel(request.Params)
+				if err != nil {
+					m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidParams, err.Error(), request.Id)
+					return nil
+				}
+				m.EventMcpRequestLoggingSetLevel(parsed, request.Id)
 			}
 		case "ping":
 			result := json.RawMessage(`{}`)
 			m.jsonRpcTransport.SendJsonRpcResponse(result, request.Id)
 		default:
+			// the unknown notifications are ignored, they can't be answered
+			if request.Id == nil {
+				m.logger.Info("unknown notification", types.LogArg{
+					"method": request.Method,
+				})
+				return nil
+			}
 			m.jsonRpcTransport.SendError(jsonrpc.RpcMethodNotFound, fmt.Sprintf("unknown method: %s", request.Method), request.Id)
 		}
 	} else {
@@ -141,22 +219,22 @@ func (m *McpServer) handleIncomingMessage(
 }
 
 func (m *McpServer) EventMcpRequestInitialize(params *mcp.JsonRpcRequestInitializeParams, reqId *jsonrpc.JsonRpcRequestId) {
-	// store client information
-	if params.ProtocolVersion != mcp.ProtocolVersion {
-		m.logger.Error("protocol version mismatch", types.LogArg{
-			"expected": mcp.ProtocolVersion,
-			"received": params.ProtocolVersion,
+	// the client decides to disconnect if it does not support the version we answer
+	protocolVersion := mcp.NegotiateProtocolVersion(params.ProtocolVersion)
+	if protocolVersion != params.ProtocolVersion {
+		m.logger.Info("protocol version not supported, proposing the latest version", types.LogArg{
+			"requested": params.ProtocolVersion,
+			"proposed":  protocolVersion,
 		})
-		m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidRequest, "protocol version mismatch", reqId)
-		return
 	}
-	// we store the client information
-	m.clientName = params.ClientInfo.Name
-	m.clientVersion = params.ClientInfo.Version
+
+	// we store the client information and capabilities
+	session := newClientSession(m, params, protocolVersion)
+	m.session.Store(session)
 
 	// prepare response
 	response := mcp.JsonRpcResponseInitializeResult{
-		ProtocolVersion: mcp.ProtocolVersion,
+		ProtocolVersion: protocolVersion,
 		Capabilities: mcp.ServerCapabilities{
 			Tools: &mcp.ServerCapabilitiesTools{
 				ListChanged: jsonrpc.BoolPtr(true),
@@ -164,18 +242,25 @@ func (m *McpServer) EventMcpRequestInitialize(params *mcp.JsonRpcRequestInitiali
 			Prompts: &mcp.ServerCapabilitiesPrompts{
 				ListChanged: jsonrpc.BoolPtr(true),
 			},
+			Logging: &mcp.ServerCapabilitiesLogging{},
 		},
 		ServerInfo: mcp.ServerInfo{Name: m.serverName, Version: m.serverVersion},
 	}
+	if session.features.Completions {
+		response.Capabilities.Completions = &mcp.ServerCapabilitiesCompletions{}
+	}
 	m.jsonRpcTransport.SendJsonRpcResponse(&response, reqId)
 }
 func (m *McpServer) EventMcpNotificationInitialized() {
 	// that's a notification, no response is needed
-	m.isClientInitialized = true
+	m.isClientInitialized.Store(true)
+
+	// the client can't receive requests before that notification
+	m.requestRoots()
 }
 
 func (m *McpServer) EventMcpRequestToolsList(ctx context.Context, params *mcp.JsonRpcRequestToolsListParams, reqId *jsonrpc.JsonRpcRequestId) {
-	response, jsonRpcErr := m.handler.ExecuteToolsList(ctx, m.logger)
+	response, jsonRpcErr := m.handler.ExecuteToolsList(ctx, params, m.logger)
 	if jsonRpcErr != nil {
 		m.jsonRpcTransport.SendError(jsonRpcErr.Code, jsonRpcErr.Message, reqId)
 		return
@@ -183,7 +268,9 @@ func (m *McpServer) EventMcpRequestToolsList(ctx context.Context, params *mcp.Js
 	m.jsonRpcTransport.SendJsonRpcResponse(response, reqId)
 }
 
-func (m *McpServer) EventMcpRequestToolsCall(ctx context.Context, params *mcp.JsonRpcRequestToolsCallParams, reqId *jsonrpc.JsonRpcRequestId) {
+// EventMcpRequestToolsCall runs in its own goroutine, the session is
+// read before, nil if the client is not initialized
+func (m *McpServer) EventMcpRequestToolsCall(ctx context.Context, session *clientSession, params *mcp.JsonRpcRequestToolsCallParams, reqId *jsonrpc.JsonRpcRequestId) {
 	// we retrieve the tool name and the arguments
 	toolName := params.Name
 	arguments := params.Arguments
@@ -192,11 +279,20 @@ func (m *McpServer) EventMcpRequestToolsCall(ctx context.Context, params *mcp.Js
 		"arguments": arguments,
 	})
 
-	response, jsonRpcErr := m.handler.ExecuteToolCall(ctx, params, m.logger)
+	response, jsonRpcErr := m.handler.ExecuteToolCall(sessionContext(ctx, session), params, m.handlerLogger(toolName))
 	if jsonRpcErr != nil {
 		m.jsonRpcTransport.SendError(jsonRpcErr.Code, jsonRpcErr.Message, reqId)
 		return
 	}
+	if gated, ok := response.(featureGatedResult); ok {
+		// the features of the latest versions are removed
+		// if the client is not initialized
+		features := mcp.ProtocolFeatures{}
+		if session != nil {
+			features = session.features
+		}
+		gated.ApplyProtocolFeatures(features)
+	}
 
 	// we send the response
 	m.jsonRpcTransport.SendJsonRpcResponse(response, reqId)
@@ -207,11 +303,27 @@ func (m *McpServer) EventMcpRequestResourcesList(params *mcp.JsonRpcRequestResou
 		Resources: make([]mcp.ResourceDescription, 0),
 	}
 
+	// there is no resource yet, but the cursor is checked like for the other lists
+	if _, _, _, err := pagination.Page([]string{}, params.Cursor, pagination.DefaultPageSize); err != nil {
+		m.jsonRpcTransport.SendError(jsonrpc.RpcInvalidParams, err.Error(), reqId)
+		return
+	}
+
 	m.jsonRpcTransport.SendJsonRpcResponse(&response, reqId)
 }
 
 func (m *McpServer) EventMcpRequestPromptsList(ctx context.Context, params *mcp.JsonRpcRequestPromptsListParams, reqId *jsonrpc.JsonRpcRequestId) {
-	response, jsonRpcErr := m.handler.ExecutePromptsList(ctx, m.logger)
+	response, jsonRpcErr := m.handler.ExecutePromptsList(ctx, params, m.logger)
+	if jsonRpcErr != nil {
+		m.jsonRpcTransport.SendError(jsonRpcErr.Code, jsonRpcErr.Message, reqId)
+		return
+	}
+	m.jsonRpcTransport.SendJsonRpcResponse(response, reqId)
+}
+
+// EventMcpRequestPromptsGet runs in its own goroutine, see EventMcpRequestToolsCall
+func (m *McpServer) EventMcpRequestPromptsGet(ctx context.Context, session *clientSession, params *mcp.JsonRpcRequestPromptsGetParams, reqId *jsonrpc.JsonRpcRequestId) {
+	response, jsonRpcErr := m.handler.ExecutePromptGet(sessionContext(ctx, session), params, m.handlerLogger(params.Name))
 	if jsonRpcErr != nil {
 		m.jsonRpcTransport.SendError(jsonRpcErr.Code, jsonRpcErr.Message, reqId)
 		return
@@ -219,8 +331,9 @@ func (m *McpServer) EventMcpRequestPromptsList(ctx context.Context, params *mcp.
 	m.jsonRpcTransport.SendJsonRpcResponse(response, reqId)
 }
 
-func (m *McpServer) EventMcpRequestPromptsGet(ctx context.Context, params *mcp.JsonRpcRequestPromptsGetParams, reqId *jsonrpc.JsonRpcRequestId) {
-	response, jsonRpcErr := m.handler.ExecutePromptGet(ctx, params, m.logger)
+// EventMcpRequestCompletionComplete runs in its own goroutine, see EventMcpRequestToolsCall
+func (m *McpServer) EventMcpRequestCompletionComplete(ctx context.Context, session *clientSession, params *mcp.JsonRpcRequestCompletionCompleteParams, reqId *jsonrpc.JsonRpcRequestId) {
+	response, jsonRpcErr := m.handler.ExecuteCompletion(sessionContext(ctx, session), params, m.handlerLogger("completion"))
 	if jsonRpcErr != nil {
 		m.jsonRpcTransport.SendError(jsonRpcErr.Code, jsonRpcErr.Message, reqId)
 		return
@@ -0,0 +1,123 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/providers/sdk"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// nextResponse returns the next response sent by the server, the
+// notifications and requests sent in the meantime are skipped
+func nextResponse(t *testing.T, serverTransport *transport.InProcessTransport) map[string]interface{} {
+	for {
+		select {
+		case message := <-serverTransport.Outgoing():
+			response := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(message, &response))
+			if _, isRequest := response["method"]; !isRequest {
+				return response
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no response from the server")
+			return nil
+		}
+	}
+}
+
+func TestInvalidListParams(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("invalid", "0.0.1")
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	for index, method := range []string{"tools/list", "resources/list", "prompts/list", "prompts/get"} {
+		assert.NoError(t, serverTransport.Deliver(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":[1]}`, index+2, method))))
+		response := nextResponse(t, serverTransport)
+		assert.Equal(t, float64(index+2), response["id"], method)
+		assert.NotNil(t, response["error"], method)
+	}
+
+	// the server is still running
+	assert.Equal(t, map[string]interface{}{}, exchange(t, serverTransport, `{"jsonrpc":"2.0","id":10,"method":"ping"}`))
+}
+
+func TestServerKeepsDefinitionLogger(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("logger", "0.0.1")
+	_, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the logger built by the server is not stored in the definition
+	assert.Nil(t, definition.(*sdk.SdkServerDefinition).Logger())
+}
+
+func TestToolCallWhileInitializing(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("logging", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("log", "Logs a message", func(ctx context.Context, toolCtx *speechContext, input *speechInput, output types.ToolCallResult) error {
+		for index := 0; index < 10; index++ {
+			types.GetLogger(ctx).Info("logged by the tool", types.LogArg{"index": index})
+		}
+		output.AddTextContent("ok")
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	// the tool runs in its own goroutine while the client gets
+	// initialized, run with -race to check the accesses to the session
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"log","arguments":{"text":""}}}`)))
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	response := nextResponse(t, serverTransport)
+	assert.Equal(t, float64(2), response["id"])
+}
+
+func TestBatchWithNotificationId(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("batch", "0.0.1")
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+
+	// a notification sent with an id gets an error, so that the ping is answered
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`[{"jsonrpc":"2.0","id":7,"method":"notifications/initialized"},{"jsonrpc":"2.0","id":8,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/unknown"}]`)))
+	select {
+	case message := <-serverTransport.Outgoing():
+		responses := []map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(message, &responses))
+		if assert.Len(t, responses, 2) {
+			assert.Equal(t, float64(7), responses[0]["id"])
+			assert.NotNil(t, responses[0]["error"])
+			assert.Equal(t, float64(8), responses[1]["id"])
+			assert.Equal(t, map[string]interface{}{}, responses[1]["result"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no response to the batch")
+	}
+}
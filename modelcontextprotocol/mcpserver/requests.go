@@ -0,0 +1,21 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+)
+
+// request sends a request to the client and waits for its response,
+// it must not be called from the goroutine reading the messages
+func (m *McpServer) request(ctx context.Context, method string, params interface{}) (*jsonrpc.JsonRpcResponse, error) {
+	response, err := m.jsonRpcTransport.Call(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%s failed: %s (code %d)", method, response.Error.Message, response.Error.Code)
+	}
+	return response, nil
+}
@@ -0,0 +1,45 @@
+package mcpserver
+
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// requestRoots asks the roots of the client, the response
+// is handled by EventMcpResponseRootsList
+func (m *McpServer) requestRoots() {
+	session := m.session.Load()
+	if session == nil || !session.capabilities.Roots {
+		return
+	}
+	_, err := m.jsonRpcTransport.SendRequestWithMethodAndParams(mcp.RpcRequestMethodRootsList, struct{}{})
+	if err != nil {
+		m.logger.Error("failed to request the roots", types.LogArg{
+			"error": err,
+		})
+	}
+}
+
+func (m *McpServer) EventMcpResponseRootsList(response *jsonrpc.JsonRpcResponse) {
+	session := m.session.Load()
+	if session == nil {
+		return
+	}
+	result, err := mcp.ParseJsonRpcResponseRootsList(response)
+	if err != nil {
+		m.logger.Error("invalid roots/list response", types.LogArg{
+			"error": err,
+		})
+		return
+	}
+
+	roots := make([]types.Root, 0, len(result.Roots))
+	for _, root := range result.Roots {
+		roots = append(roots, types.Root{Uri: root.Uri, Name: root.Name})
+	}
+	session.setRoots(roots)
+	m.logger.Info("roots updated", types.LogArg{
+		"roots": result.Roots,
+	})
+}
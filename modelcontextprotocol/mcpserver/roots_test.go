@@ -0,0 +1,124 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type rootsInput struct {
+	Path string `json:"path"`
+}
+
+// nextRequest returns the next request sent by the server, the
+// notifications sent in the meantime are skipped
+func nextRequest(t *testing.T, serverTransport *transport.InProcessTransport) map[string]interface{} {
+	for {
+		select {
+		case message := <-serverTransport.Outgoing():
+			request := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(message, &request))
+			if _, hasMethod := request["method"]; !hasMethod {
+				t.Fatalf("unexpected response: %s", message)
+			}
+			if _, hasId := request["id"]; hasId {
+				return request
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no request from the server")
+			return nil
+		}
+	}
+}
+
+// answerRoots answers the roots/list request of the server
+func answerRoots(t *testing.T, serverTransport *transport.InProcessTransport, uris ...string) {
+	request := nextRequest(t, serverTransport)
+	assert.Equal(t, "roots/list", request["method"])
+	roots := []map[string]interface{}{}
+	for _, uri := range uris {
+		roots = append(roots, map[string]interface{}{"uri": uri})
+	}
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result":  map[string]interface{}{"roots": roots},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, serverTransport.Deliver(response))
+}
+
+func TestRoots(t *testing.T) {
+	definition := gomcp.NewMcpServerDefinition("roots", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("roots", "Checks a path against the roots of the client", func(ctx context.Context, toolCtx *speechContext, input *rootsInput, output types.ToolCallResult) error {
+		roots, err := types.GetRoots(ctx)
+		if err != nil {
+			return err
+		}
+		uris := []string{}
+		for _, root := range roots {
+			uris = append(uris, root.Uri)
+		}
+		output.AddTextContent(fmt.Sprintf("%s %v", strings.Join(uris, ","), types.PathInRoots(roots, input.Path)))
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	callRoots := func(path string) string {
+		result := exchange(t, serverTransport, fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"roots","arguments":{"path":%q}}}`, path))
+		return result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	}
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{"roots":{"listChanged":true}},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	// the roots are requested once the client is initialized
+	answerRoots(t, serverTransport, "file:///home/user/project")
+	assert.Equal(t, "file:///home/user/project true", callRoots("/home/user/project/main.go"))
+	assert.Equal(t, "file:///home/user/project false", callRoots("/home/user/project2/main.go"))
+
+	// and again when the client notifies a change
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/roots/list_changed"}`)))
+	answerRoots(t, serverTransport, "file:///home/user/project", "file:///home/user/project2")
+	assert.Equal(t, "file:///home/user/project,file:///home/user/project2 true", callRoots("/home/user/project2/main.go"))
+}
+
+func TestRootsNotSupported(t *testing.T) {
+	var rootsErr error
+
+	definition := gomcp.NewMcpServerDefinition("roots", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("roots", "Reads the roots of the client", func(ctx context.Context, toolCtx *speechContext, input *rootsInput, output types.ToolCallResult) error {
+		_, rootsErr = types.GetRoots(ctx)
+		output.AddTextContent("ok")
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"roots","arguments":{"path":"/"}}}`)
+
+	assert.ErrorIs(t, rootsErr, types.ErrRootsNotSupported)
+}
@@ -0,0 +1,67 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+func (s *clientSession) CreateMessage(ctx context.Context, messages []types.SamplingMessage, modelPreferences *types.ModelPreferences, maxTokens int) (*types.SamplingResult, error) {
+	if !s.capabilities.Sampling {
+		return nil, types.ErrSamplingNotSupported
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("at least one message is needed")
+	}
+
+	params := &mcp.JsonRpcRequestSamplingCreateMessageParams{
+		Messages:  make([]mcp.SamplingMessage, 0, len(messages)),
+		MaxTokens: maxTokens,
+	}
+	for _, message := range messages {
+		contentType := message.Type
+		if contentType == "" {
+			contentType = types.SamplingContentText
+		}
+		params.Messages = append(params.Messages, mcp.SamplingMessage{
+			Role: message.Role,
+			Content: mcp.SamplingContent{
+				Type:     contentType,
+				Text:     message.Text,
+				Data:     message.Data,
+				MimeType: message.MimeType,
+			},
+		})
+	}
+	if modelPreferences != nil {
+		params.ModelPreferences = &mcp.ModelPreferences{
+			CostPriority:         modelPreferences.CostPriority,
+			SpeedPriority:        modelPreferences.SpeedPriority,
+			IntelligencePriority: modelPreferences.IntelligencePriority,
+		}
+		for _, hint := range modelPreferences.Hints {
+			params.ModelPreferences.Hints = append(params.ModelPreferences.Hints, mcp.ModelHint{Name: hint})
+		}
+	}
+
+	response, err := s.request(ctx, mcp.RpcRequestMethodSamplingCreateMessage, params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcp.ParseJsonRpcResponseSamplingCreateMessage(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling/createMessage response: %v", err)
+	}
+
+	return &types.SamplingResult{
+		Role:       result.Role,
+		Type:       result.Content.Type,
+		Text:       result.Content.Text,
+		Data:       result.Content.Data,
+		MimeType:   result.Content.MimeType,
+		Model:      result.Model,
+		StopReason: result.StopReason,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type summarizeInput struct {
+	Text string `json:"text"`
+}
+
+func startSummarizer(t *testing.T, capabilities string) *transport.InProcessTransport {
+	definition := gomcp.NewMcpServerDefinition("summarizer", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("summarize", "Summarizes a text with the model of the client", func(ctx context.Context, toolCtx *speechContext, input *summarizeInput, output types.ToolCallResult) error {
+		speed := 0.8
+		result, err := types.CreateMessage(ctx, []types.SamplingMessage{
+			{Role: "user", Text: "Summarize: " + input.Text},
+		}, &types.ModelPreferences{Hints: []string{"claude"}, SpeedPriority: &speed}, 100)
+		if err != nil {
+			output.AddTextContent(err.Error())
+			return nil
+		}
+		output.AddTextContent(fmt.Sprintf("%s (%s)", result.Text, result.Model))
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+
+	exchange(t, serverTransport, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":%s,"clientInfo":{"name":"test","version":"1.0"}}}`, capabilities))
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	return serverTransport
+}
+
+func TestSampling(t *testing.T) {
+	serverTransport := startSummarizer(t, `{"sampling":{}}`)
+	defer serverTransport.Close()
+
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"summarize","arguments":{"text":"a long text"}}}`)))
+
+	// the tool waits for the answer of the client
+	request := nextRequest(t, serverTransport)
+	assert.Equal(t, "sampling/createMessage", request["method"])
+	assert.Equal(t, map[string]interface{}{
+		"messages": []interface{}{
+			map[string]interface{}{"role": "user", "content": map[string]interface{}{"type": "text", "text": "Summarize: a long text"}},
+		},
+		"modelPreferences": map[string]interface{}{
+			"hints":         []interface{}{map[string]interface{}{"name": "claude"}},
+			"speedPriority": 0.8,
+		},
+		"maxTokens": float64(100),
+	}, request["params"])
+
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result": map[string]interface{}{
+			"role":       "assistant",
+			"content":    map[string]interface{}{"type": "text", "text": "short"},
+			"model":      "claude-3-5-sonnet",
+			"stopReason": "endTurn",
+		},
+	})
+	assert.NoError(t, err)
+	result := exchange(t, serverTransport, string(response))
+	assert.Equal(t, "short (claude-3-5-sonnet)", result["content"].([]interface{})[0].(map[string]interface{})["text"])
+}
+
+func TestSamplingRejected(t *testing.T) {
+	serverTransport := startSummarizer(t, `{"sampling":{}}`)
+	defer serverTransport.Close()
+
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"summarize","arguments":{"text":"a long text"}}}`)))
+	request := nextRequest(t, serverTransport)
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"error":   map[string]interface{}{"code": -1, "message": "User rejected sampling request"},
+	})
+	assert.NoError(t, err)
+	result := exchange(t, serverTransport, string(response))
+	assert.Equal(t, "sampling/createMessage failed: User rejected sampling request (code -1)", result["content"].([]interface{})[0].(map[string]interface{})["text"])
+}
+
+func TestSamplingNotSupported(t *testing.T) {
+	serverTransport := startSummarizer(t, `{}`)
+	defer serverTransport.Close()
+
+	result := exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"summarize","arguments":{"text":"a long text"}}}`)
+	assert.Equal(t, types.ErrSamplingNotSupported.Error(), result["content"].([]interface{})[0].(map[string]interface{})["text"])
+}
@@ -2,7 +2,9 @@ package mcpserver
 
 import (
 	"fmt"
+	"sync/atomic"
 
+	"github.com/llmcontext/gomcp/inspector"
 	"github.com/llmcontext/gomcp/logger"
 	"github.com/llmcontext/gomcp/modelcontextprotocol"
 	"github.com/llmcontext/gomcp/providers"
@@ -12,14 +14,22 @@ import (
 )
 
 type McpServer struct {
-	logger        types.Logger
+	logger types.Logger
+	// forwards the logs of the handlers to the client
+	clientLogger  *logger.ClientLogger
 	serverName    string
 	serverVersion string
 	handler       modelcontextprotocol.McpServerEventHandler
-	// used by protocol
-	clientName          string
-	clientVersion       string
-	isClientInitialized bool
+	// reloads the prompts files if they are watched
+	sdkServerDefinition *sdk.SdkServerDefinition
+	// record the protocol messages in that file, if set
+	protocolDebugFile string
+	// web inspector, nil if not enabled
+	inspector *inspector.Inspector
+	// negotiated during the initialization, nil before. The tool calls
+	// and the prompts watcher read these fields from their own goroutine
+	session             atomic.Pointer[clientSession]
+	isClientInitialized atomic.Bool
 	lastRequestId       int
 	jsonRpcTransport    *transport.JsonRpcTransport
 }
@@ -32,33 +42,50 @@ func NewMcpSdkServer(serverDefinition types.McpSdkServerDefinition, debug bool)
 		return nil, fmt.Errorf("invalid configuration type: expected *sdk.SdkServerDefinition, got %T", serverDefinition)
 	}
 
-	// we build the configuration data
-	loggingInfo := &logger.LoggingInfo{
-		Level:      sdkServerDefinition.DebugLevel(),
-		File:       sdkServerDefinition.DebugFile(),
-		WithStderr: false,
-	}
+	// we use the logger provided by the definition, if any
+	serverLogger := sdkServerDefinition.Logger()
+	if serverLogger == nil {
+		// we build the configuration data
+		loggingInfo := &logger.LoggingInfo{
+			Level:      sdkServerDefinition.DebugLevel(),
+			File:       sdkServerDefinition.DebugFile(),
+			WithStderr: sdkServerDefinition.DebugWithStderr(),
+			Append:     sdkServerDefinition.DebugAppend(),
+			Rotation:   sdkServerDefinition.DebugRotation(),
+		}
 
-	// we initialize the logger
-	logger, err := logger.NewLogger(loggingInfo, debug)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize logger: %v", err)
+		// we initialize the logger
+		var err error
+		serverLogger, err = logger.NewLogger(loggingInfo, debug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize logger: %v", err)
+		}
 	}
 
 	// we create the MCP server handler
-	mcpServerNotifications, err := providers.NewProviderMcpServerHandler(sdkServerDefinition, logger)
+	mcpServerNotifications, err := providers.NewProviderMcpServerHandler(sdkServerDefinition, serverLogger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &McpServer{
-		logger:        logger,
+	mcpServer := &McpServer{
+		logger:        serverLogger,
 		serverName:    sdkServerDefinition.ServerName(),
 		serverVersion: sdkServerDefinition.ServerVersion(),
 		handler:       mcpServerNotifications,
 		lastRequestId: 0,
-	}, nil
 
+		sdkServerDefinition: sdkServerDefinition,
+
+		protocolDebugFile: sdkServerDefinition.ProtocolDebugFile(),
+	}
+	mcpServer.clientLogger = newClientLogger(mcpServer)
+
+	if inspectorAddress := sdkServerDefinition.InspectorAddress(); inspectorAddress != "" {
+		mcpServer.inspector = inspector.NewInspector(inspectorAddress, mcpServerNotifications, serverLogger)
+	}
+
+	return mcpServer, nil
 }
 
 func (mcp *McpServer) StdioTransport() types.Transport {
@@ -0,0 +1,87 @@
+package mcpserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// clientSession is the state of the client negotiated during the initialization
+type clientSession struct {
+	clientName      string
+	clientVersion   string
+	protocolVersion string
+	features        mcp.ProtocolFeatures
+	capabilities    *types.ClientCapabilities
+	// sends a request to the client and waits for its response
+	request func(ctx context.Context, method string, params interface{}) (*jsonrpc.JsonRpcResponse, error)
+
+	// the roots are updated while the handlers read them
+	mu    sync.Mutex
+	roots []types.Root
+}
+
+func newClientSession(m *McpServer, params *mcp.JsonRpcRequestInitializeParams, protocolVersion string) *clientSession {
+	capabilities := &types.ClientCapabilities{
+		Sampling:     params.Capabilities.Sampling != nil,
+		Elicitation:  params.Capabilities.Elicitation != nil,
+		Experimental: params.Capabilities.Experimental,
+	}
+	if params.Capabilities.Roots != nil {
+		capabilities.Roots = true
+		capabilities.RootsListChanged = params.Capabilities.Roots.ListChanged
+	}
+
+	return &clientSession{
+		clientName:      params.ClientInfo.Name,
+		clientVersion:   params.ClientInfo.Version,
+		protocolVersion: protocolVersion,
+		features:        mcp.FeaturesOf(protocolVersion),
+		capabilities:    capabilities,
+		request:         m.request,
+	}
+}
+
+func (s *clientSession) ClientName() string {
+	return s.clientName
+}
+
+func (s *clientSession) ClientVersion() string {
+	return s.clientVersion
+}
+
+func (s *clientSession) ProtocolVersion() string {
+	return s.protocolVersion
+}
+
+func (s *clientSession) Capabilities() *types.ClientCapabilities {
+	return s.capabilities
+}
+
+func (s *clientSession) Roots() ([]types.Root, error) {
+	if !s.capabilities.Roots {
+		return nil, types.ErrRootsNotSupported
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	roots := make([]types.Root, len(s.roots))
+	copy(roots, s.roots)
+	return roots, nil
+}
+
+func (s *clientSession) setRoots(roots []types.Root) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roots = roots
+}
+
+// sessionContext gives the handlers access to the session of the client
+func sessionContext(ctx context.Context, session *clientSession) context.Context {
+	if session == nil {
+		return ctx
+	}
+	return types.ContextWithClientSession(ctx, session)
+}
@@ -0,0 +1,57 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type capabilitiesInput struct{}
+
+func TestClientCapabilitiesInContext(t *testing.T) {
+	var capabilities *types.ClientCapabilities
+	var session types.ClientSession
+
+	definition := gomcp.NewMcpServerDefinition("capabilities", "0.0.1")
+	tools := definition.WithTools(nil, speechInit)
+	tools.AddTool("capabilities", "Reads the capabilities of the client", func(ctx context.Context, toolCtx *speechContext, input *capabilitiesInput, output types.ToolCallResult) error {
+		capabilities = types.GetClientCapabilities(ctx)
+		session = types.GetClientSession(ctx)
+		output.AddTextContent("ok")
+		return nil
+	})
+	server, err := gomcp.NewModelContextProtocolServer(definition)
+	if !assert.NoError(t, err) {
+		return
+	}
+	serverTransport := transport.NewInProcessTransport()
+	go server.Start(serverTransport)
+	defer serverTransport.Close()
+
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{"roots":{"listChanged":true},"sampling":{},"experimental":{"tracing":{"level":2}}},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"capabilities","arguments":{}}}`)
+
+	assert.Equal(t, &types.ClientCapabilities{
+		Roots:            true,
+		RootsListChanged: true,
+		Sampling:         true,
+		Elicitation:      false,
+		Experimental:     map[string]interface{}{"tracing": map[string]interface{}{"level": float64(2)}},
+	}, capabilities)
+	if assert.NotNil(t, session) {
+		assert.Equal(t, "test", session.ClientName())
+		assert.Equal(t, "1.0", session.ClientVersion())
+		assert.Equal(t, "2025-06-18", session.ProtocolVersion())
+	}
+}
+
+func TestClientCapabilitiesOutsideOfRequest(t *testing.T) {
+	assert.Nil(t, types.GetClientSession(context.Background()))
+	assert.Equal(t, &types.ClientCapabilities{}, types.GetClientCapabilities(context.Background()))
+}
@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/llmcontext/gomcp/inspector"
 	"github.com/llmcontext/gomcp/types"
 	"golang.org/x/sync/errgroup"
 )
@@ -41,24 +42,27 @@ func (m *McpServer) Start(transport types.Transport) error {
 	})
 
 	// Start inspector if it was enabled
-	// if mcp.inspector != nil {
-	// 	eg.Go(func() error {
-	// 		mcp.logger.Info("[B] Starting inspector", types.LogArg{})
-	// 		err := mcp.inspector.Start(egCtx)
-	// 		if err != nil {
-	// 			// check if the error is because the context was cancelled
-	// 			if errors.Is(err, context.Canceled) {
-	// 				mcp.logger.Info("[B.1] context cancelled, stopping inspector", types.LogArg{})
-	// 			} else {
-	// 				mcp.logger.Error("[B.2] error starting inspector", types.LogArg{
-	// 					"error": err,
-	// 				})
-	// 			}
-	// 		}
-	// 		mcp.logger.Info("[B.3] inspector stopped", types.LogArg{})
-	// 		return err
-	// 	})
-	// }
+	if m.inspector != nil {
+		eg.Go(func() error {
+			m.logger.Info("Starting inspector", types.LogArg{})
+			err := m.inspector.Start(egCtx)
+			if err != nil && !inspector.IsClosed(err) {
+				// the server keeps running without the inspector,
+				// eg. if the port is already in use
+				m.logger.Error("error starting inspector", types.LogArg{
+					"error": err,
+				})
+			}
+			m.logger.Info("inspector stopped", types.LogArg{})
+			return nil
+		})
+	}
+
+	// reload the prompts files when they change, if enabled
+	eg.Go(func() error {
+		m.sdkServerDefinition.WatchPrompts(egCtx, m.onPromptsReload)
+		return nil
+	})
 
 	eg.Go(func() error {
 		m.logger.Info("Starting MCP protocol", types.LogArg{})
@@ -0,0 +1,102 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/transport"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type speechContext struct{}
+
+func speechInit(ctx context.Context) (*speechContext, error) {
+	return &speechContext{}, nil
+}
+
+type speechInput struct {
+	Text string `json:"text"`
+}
+
+func speak(ctx context.Context, toolCtx *speechContext, input *speechInput, output types.ToolCallResult) error {
+	extended, ok := output.(types.ExtendedToolCallResult)
+	if !ok {
+		return fmt.Errorf("unexpected result type %T", output)
+	}
+	extended.AddAudioContent("audio/wav", "UklGRg==")
+	extended.SetStructuredContent(map[string]interface{}{"words": 2})
+	return nil
+}
+
+// exchange sends a request to the server and returns the result of its
+// response, the notifications and requests sent in the meantime are skipped
+func exchange(t *testing.T, serverTransport *transport.InProcessTransport, request string) map[string]interface{} {
+	assert.NoError(t, serverTransport.Deliver(json.RawMessage(request)))
+	for {
+		select {
+		case message := <-serverTransport.Outgoing():
+			response := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(message, &response))
+			if _, isRequest := response["method"]; isRequest {
+				continue
+			}
+			result, ok := response["result"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("unexpected response: %s", message)
+			}
+			return result
+		case <-time.After(5 * time.Second):
+			t.Fatal("no response from the server")
+			return nil
+		}
+	}
+}
+
+func TestProtocolVersions(t *testing.T) {
+	tests := []struct {
+		requested        string
+		negotiated       string
+		completions      bool
+		audio            bool
+		structuredOutput bool
+	}{
+		{mcp.ProtocolVersion20241105, mcp.ProtocolVersion20241105, false, false, false},
+		{mcp.ProtocolVersion20250326, mcp.ProtocolVersion20250326, true, true, false},
+		{mcp.ProtocolVersion20250618, mcp.ProtocolVersion20250618, true, true, true},
+		{"2099-01-01", mcp.ProtocolVersion, true, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.requested, func(t *testing.T) {
+			definition := gomcp.NewMcpServerDefinition("speech", "0.0.1")
+			tools := definition.WithTools(nil, speechInit)
+			tools.AddTool("speak", "Reads a text", speak)
+			server, err := gomcp.NewModelContextProtocolServer(definition)
+			if !assert.NoError(t, err) {
+				return
+			}
+			serverTransport := transport.NewInProcessTransport()
+			go server.Start(serverTransport)
+			defer serverTransport.Close()
+
+			result := exchange(t, serverTransport, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":%q,"capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`, test.requested))
+			assert.Equal(t, test.negotiated, result["protocolVersion"])
+			_, completions := result["capabilities"].(map[string]interface{})["completions"]
+			assert.Equal(t, test.completions, completions)
+			assert.NoError(t, serverTransport.Deliver(json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+			result = exchange(t, serverTransport, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"speak","arguments":{"text":"hello world"}}}`)
+			content := result["content"].([]interface{})
+			assert.Equal(t, test.audio, content[0].(map[string]interface{})["type"] == "audio")
+			assert.Equal(t, map[string]interface{}{"type": "text", "text": `{"words":2}`}, content[1])
+			_, structured := result["structuredContent"]
+			assert.Equal(t, test.structuredOutput, structured)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/llmcontext/gomcp/pkg/jsonschema"
+)
+
+// LoadServerConfigurationFile reads and validates a JSON configuration file.
+// Relative paths found in the configuration (prompts, log and protocol debug files) are resolved
+// against the directory of the configuration file.
+func LoadServerConfigurationFile(configFilePath string) (*ServerConfiguration, error) {
+	jsonData, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseServerConfiguration(jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", configFilePath, err)
+	}
+
+	configDir := filepath.Dir(configFilePath)
+	if config.Prompts != nil && config.Prompts.File != "" && !filepath.IsAbs(config.Prompts.File) {
+		config.Prompts.File = filepath.Join(configDir, config.Prompts.File)
+	}
+
+	if config.Logging != nil && config.Logging.File != "" && !filepath.IsAbs(config.Logging.File) {
+		config.Logging.File = filepath.Join(configDir, config.Logging.File)
+	}
+
+	if config.Logging != nil && config.Logging.ProtocolDebugFile != "" && !filepath.IsAbs(config.Logging.ProtocolDebugFile) {
+		config.Logging.ProtocolDebugFile = filepath.Join(configDir, config.Logging.ProtocolDebugFile)
+	}
+
+	return config, nil
+}
+
+// ParseServerConfiguration validates the JSON data against the schema
+// of the ServerConfiguration struct before unmarshalling it
+func ParseServerConfiguration(jsonData []byte) (*ServerConfiguration, error) {
+	// retrieve the schema for the ServerConfiguration struct
+	configSchema, _, err := jsonschema.GetFullSchemaFromInterface(reflect.TypeOf(&ServerConfiguration{}))
+	if err != nil {
+		return nil, fmt.Errorf("error generating schema for ServerConfiguration")
+	}
+
+	// validate the json data against the schema
+	err = jsonschema.ValidateJsonSchemaWithBytes(configSchema, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ServerConfiguration
+	if err := json.Unmarshal(jsonData, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadServerConfigurationFileResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "gomcp.json")
+	assert.NoError(t, os.WriteFile(configFile, []byte(`{
+		"serverInfo": {"name": "gomcp", "version": "0.1.0"},
+		"logging": {"file": "logs/gomcp.log", "level": "debug", "protocolDebugFile": "/tmp/protocol.jsonl"},
+		"prompts": {"file": "prompts.yaml"}
+	}`), 0644))
+
+	config, err := LoadServerConfigurationFile(configFile)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the relative paths are resolved against the directory of the file
+	assert.Equal(t, filepath.Join(dir, "logs", "gomcp.log"), config.Logging.File)
+	assert.Equal(t, filepath.Join(dir, "prompts.yaml"), config.Prompts.File)
+	// the absolute ones are kept
+	assert.Equal(t, "/tmp/protocol.jsonl", config.Logging.ProtocolDebugFile)
+}
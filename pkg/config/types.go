@@ -0,0 +1,49 @@
+package config
+
+// ServerConfiguration represents the root of the JSON configuration file
+type ServerConfiguration struct {
+	ServerInfo ServerInfo                   `json:"serverInfo"`
+	Logging    *LoggingConfiguration        `json:"logging,omitempty"`
+	Prompts    *PromptsConfiguration        `json:"prompts,omitempty"`
+	Inspector  *InspectorConfiguration      `json:"inspector,omitempty"`
+	Pagination *PaginationConfiguration     `json:"pagination,omitempty"`
+	Tools      []*ToolProviderConfiguration `json:"tools,omitempty"`
+}
+
+type ServerInfo struct {
+	Name    string `json:"name" jsonschema_description:"the name of the server, sent to the client during initialization."`
+	Version string `json:"version" jsonschema_description:"the version of the server, sent to the client during initialization."`
+}
+
+type LoggingConfiguration struct {
+	File       string `json:"file,omitempty" jsonschema_description:"the path to the log file."`
+	Level      string `json:"level,omitempty" jsonschema:"enum=debug,enum=info,enum=warn,enum=error" jsonschema_description:"the logging level."`
+	WithStderr bool   `json:"withStderr,omitempty" jsonschema_description:"also write the logs to the standard error stream."`
+	Append     bool   `json:"append,omitempty" jsonschema_description:"keep the content of an existing log file instead of rotating or deleting it."`
+	MaxSize    int    `json:"maxSize,omitempty" jsonschema:"minimum=0" jsonschema_description:"the size in megabytes above which the log file is rotated."`
+	MaxAge     string `json:"maxAge,omitempty" jsonschema_description:"the duration after which the log file is rotated, eg. 24h."`
+	MaxBackups int    `json:"maxBackups,omitempty" jsonschema:"minimum=0" jsonschema_description:"the number of rotated log files to keep, all of them are kept if not set."`
+	Compress   bool   `json:"compress,omitempty" jsonschema_description:"compress the rotated log files with gzip."`
+
+	ProtocolDebugFile string `json:"protocolDebugFile,omitempty" jsonschema_description:"the path to a JSONL file recording all the protocol messages."`
+}
+
+type PromptsConfiguration struct {
+	File  string `json:"file" jsonschema_description:"the path to the YAML file containing the prompts."`
+	Watch bool   `json:"watch,omitempty" jsonschema_description:"reload the prompts when the file changes."`
+}
+
+type InspectorConfiguration struct {
+	Enabled       bool   `json:"enabled" jsonschema_description:"start the web inspector alongside the server."`
+	ListenAddress string `json:"listenAddress,omitempty" jsonschema_description:"the address the inspector listens on, 127.0.0.1:8090 by default."`
+}
+
+type PaginationConfiguration struct {
+	PageSize int `json:"pageSize" jsonschema:"minimum=0" jsonschema_description:"the number of tools or prompts returned by a list request, 0 to return all of them."`
+}
+
+type ToolProviderConfiguration struct {
+	Name          string      `json:"name" jsonschema_description:"the name of the tool provider."`
+	Description   string      `json:"description,omitempty" jsonschema_description:"the description of the tool provider."`
+	Configuration interface{} `json:"configuration,omitempty" jsonschema_description:"the configuration passed to the tool provider init function."`
+}
@@ -37,3 +37,38 @@ func ValidateJsonSchemaWithObject(schema *jsonschema.Schema, data interface{}) e
 	}
 	return ValidateJsonSchemaWithBytes(schema, jsonBytes)
 }
+
+// ValidationError is a violation of the schema, located by its
+// path in the document, eg. prompts.0.name
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+// GetValidationErrors returns all the violations of the schema,
+// the error is only set if the validation could not run
+func GetValidationErrors(schema *jsonschema.Schema, data []byte) ([]*ValidationError, error) {
+	schemaBytes, _ := json.MarshalIndent(schema, "", "    ")
+
+	schemaLoader := gojsonschema.NewStringLoader(string(schemaBytes))
+
+	documentLoader := gojsonschema.NewStringLoader(string(data))
+
+	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("schema validation error: %v", err)
+	}
+
+	validationErrors := []*ValidationError{}
+	for _, desc := range result.Errors() {
+		field := desc.Field()
+		if field == gojsonschema.STRING_CONTEXT_ROOT {
+			field = ""
+		}
+		validationErrors = append(validationErrors, &ValidationError{
+			Field:   field,
+			Message: desc.Description(),
+		})
+	}
+	return validationErrors, nil
+}
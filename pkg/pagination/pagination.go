@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// DefaultPageSize is the number of items returned by a list request
+const DefaultPageSize = 100
+
+// the cursor is the name of the last item of the previous page, so that
+// the next page does not depend on the items added or removed since
+const cursorPrefix = "after:"
+
+// Page returns the range of keys to return for the cursor, and the cursor
+// of the next page, nil if it is the last page. The keys must be unique and
+// in a stable order. All the keys are returned if pageSize is 0 or less
+func Page(keys []string, cursor *string, pageSize int) (int, int, *string, error) {
+	start := 0
+	if cursor != nil && *cursor != "" {
+		after, err := decodeCursor(*cursor)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		start = -1
+		for index, key := range keys {
+			if key == after {
+				start = index + 1
+				break
+			}
+		}
+		if start < 0 {
+			return 0, 0, nil, fmt.Errorf("invalid cursor: %s no longer exists", after)
+		}
+	}
+
+	if pageSize <= 0 || start+pageSize >= len(keys) {
+		return start, len(keys), nil, nil
+	}
+	end := start + pageSize
+	nextCursor := encodeCursor(keys[end-1])
+	return start, end, &nextCursor, nil
+}
+
+func encodeCursor(after string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + after))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return "", fmt.Errorf("invalid cursor")
+	}
+	return strings.TrimPrefix(string(decoded), cursorPrefix), nil
+}
@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	start, end, next, err := Page(keys, nil, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys[start:end])
+
+	start, end, next, err = Page(keys, next, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, keys[start:end])
+
+	// the cursor stays valid if an item is added before it
+	keys = []string{"0", "a", "b", "c", "d", "e"}
+	start, end, next, err = Page(keys, next, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e"}, keys[start:end])
+	assert.Nil(t, next)
+
+	start, end, next, err = Page(keys, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, keys, keys[start:end])
+	assert.Nil(t, next)
+
+	invalid := "not a cursor"
+	_, _, _, err = Page(keys, &invalid, 2)
+	assert.EqualError(t, err, "invalid cursor")
+
+	removed := encodeCursor("z")
+	_, _, _, err = Page(keys, &removed, 2)
+	assert.EqualError(t, err, "invalid cursor: z no longer exists")
+}
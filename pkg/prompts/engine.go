@@ -0,0 +1,60 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// the engines rendering the prompt of a PromptDefinition
+const (
+	// Go text/template, the default
+	EngineText = "text"
+	// Go text/template with the helper functions of HelperFuncs
+	EngineSprig = "sprig"
+	// the prompt is sent as is, without templating
+	EngineLiteral = "literal"
+)
+
+// PromptEngine returns the engine of the prompt, EngineText if not set
+func PromptEngine(prompt *PromptDefinition) string {
+	if prompt.Engine == "" {
+		return EngineText
+	}
+	return prompt.Engine
+}
+
+// RenderPrompt renders the prompt with the arguments, funcs are the
+// functions made available to the templates in addition to the helpers
+// of the engine, they may be nil
+func RenderPrompt(prompt *PromptDefinition, arguments map[string]string, funcs template.FuncMap) (string, error) {
+	return RenderTemplate(prompt.Name, PromptEngine(prompt), prompt.Prompt, arguments, funcs)
+}
+
+// RenderTemplate renders text with the given engine
+func RenderTemplate(name string, engine string, text string, arguments map[string]string, funcs template.FuncMap) (string, error) {
+	tmpl := template.New(name)
+	switch engine {
+	case EngineLiteral:
+		return text, nil
+	case EngineText:
+	case EngineSprig:
+		tmpl = tmpl.Funcs(HelperFuncs())
+	default:
+		return "", fmt.Errorf("unknown template engine: %s", engine)
+	}
+	if funcs != nil {
+		tmpl = tmpl.Funcs(funcs)
+	}
+
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %s", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, arguments); err != nil {
+		return "", fmt.Errorf("invalid prompt rendering: %s", err)
+	}
+	return rendered.String(), nil
+}
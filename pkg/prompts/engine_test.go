@@ -0,0 +1,38 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	arguments := map[string]string{"code": `if a < b && c > "d" {}`, "language": ""}
+
+	tests := []struct {
+		name     string
+		engine   string
+		prompt   string
+		funcs    template.FuncMap
+		expected string
+	}{
+		{"text is not escaped", "", "Review {{.code}}", nil, `Review if a < b && c > "d" {}`},
+		{"explicit text", EngineText, "{{.code}}", nil, `if a < b && c > "d" {}`},
+		{"sprig helpers", EngineSprig, `{{.language | default "go" | upper}} {{trunc 4 .code}}`, nil, "GO if a"},
+		{"literal", EngineLiteral, "{{.code}}", nil, "{{.code}}"},
+		{"registry funcs", EngineText, "{{shout .code}}", template.FuncMap{"shout": strings.ToUpper}, `IF A < B && C > "D" {}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompt := &PromptDefinition{Name: "review", Engine: test.engine, Prompt: test.prompt}
+			rendered, err := RenderPrompt(prompt, arguments, test.funcs)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rendered)
+		})
+	}
+
+	_, err := RenderPrompt(&PromptDefinition{Name: "review", Prompt: "{{upper .code}}"}, arguments, nil)
+	assert.ErrorContains(t, err, `function "upper" not defined`)
+}
@@ -0,0 +1,145 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// HelperFuncs returns the functions of the sprig engine, a subset of the
+// sprig library (https://masterminds.github.io/sprig/) with the same names
+// and the same order of arguments, so that the value can be piped:
+//
+//	{{.name | trim | upper}}
+//	{{.language | default "english"}}
+func HelperFuncs() template.FuncMap {
+	return template.FuncMap{
+		// strings
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+		"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+		"trunc":      trunc,
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+		"squote":     func(s string) string { return "'" + s + "'" },
+		"indent":     indent,
+		"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+		"splitList":  func(sep string, s string) []string { return strings.Split(s, sep) },
+		"join":       join,
+
+		// defaults
+		"default":  defaultValue,
+		"empty":    isEmpty,
+		"coalesce": coalesce,
+
+		// lists and encoding
+		"list":   func(values ...interface{}) []interface{} { return values },
+		"toJson": toJson,
+
+		// dates
+		"now":  time.Now,
+		"date": func(layout string, date time.Time) string { return date.Format(layout) },
+	}
+}
+
+func title(s string) string {
+	runes := []rune(s)
+	startOfWord := true
+	for i, r := range runes {
+		if unicode.IsSpace(r) {
+			startOfWord = true
+			continue
+		}
+		if startOfWord {
+			runes[i] = unicode.ToUpper(r)
+		}
+		startOfWord = false
+	}
+	return string(runes)
+}
+
+// trunc keeps the first length characters, or the last ones if length is negative
+func trunc(length int, s string) string {
+	runes := []rune(s)
+	if length >= 0 && len(runes) > length {
+		return string(runes[:length])
+	}
+	if length < 0 && len(runes) > -length {
+		return string(runes[len(runes)+length:])
+	}
+	return s
+}
+
+func indent(spaces int, s string) string {
+	padding := strings.Repeat(" ", spaces)
+	return padding + strings.ReplaceAll(s, "\n", "\n"+padding)
+}
+
+func join(sep string, values interface{}) string {
+	value := reflect.ValueOf(values)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(values)
+	}
+	parts := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		parts = append(parts, fmt.Sprint(value.Index(i).Interface()))
+	}
+	return strings.Join(parts, sep)
+}
+
+func defaultValue(def interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || isEmpty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+func toJson(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,234 @@
+package prompts
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"text/template"
+	"unicode/utf8"
+)
+
+// the types of RenderedMessage
+const (
+	MessageTypeText     = "text"
+	MessageTypeImage    = "image"
+	MessageTypeResource = "resource"
+)
+
+// RenderedMessage is a message of a prompt once rendered with the arguments
+type RenderedMessage struct {
+	Role string
+	Type string
+	// the text of a text message or of a text resource
+	Text string
+	// the base64 encoded content of an image or of a binary resource
+	Data     string
+	MimeType string
+	// the uri of a resource
+	Uri string
+}
+
+// CheckPromptDefinition checks the rules that the schema of the
+// prompts file can't express
+func CheckPromptDefinition(prompt *PromptDefinition) error {
+	if prompt.Prompt != "" && len(prompt.Messages) > 0 {
+		return fmt.Errorf("prompt and messages can't be both set")
+	}
+	if prompt.Prompt == "" && len(prompt.Messages) == 0 {
+		return fmt.Errorf("either prompt or messages must be set")
+	}
+	for index, message := range prompt.Messages {
+		contents := 0
+		if message.Text != "" {
+			contents++
+		}
+		if message.Image != nil {
+			contents++
+		}
+		if message.Resource != nil {
+			contents++
+			if (message.Resource.File == "") == (message.Resource.Text == "") {
+				return fmt.Errorf("message %d: the resource needs either a file or a text", index+1)
+			}
+		}
+		if contents != 1 {
+			return fmt.Errorf("message %d: exactly one of text, image and resource must be set", index+1)
+		}
+	}
+	return nil
+}
+
+// RenderMessages renders all the messages of the prompt, a prompt defined
+// with the prompt field gives a single text message from the user
+func RenderMessages(prompt *PromptDefinition, arguments map[string]string, funcs template.FuncMap) ([]*RenderedMessage, error) {
+	if len(prompt.Messages) == 0 {
+		text, err := RenderPrompt(prompt, arguments, funcs)
+		if err != nil {
+			return nil, err
+		}
+		return []*RenderedMessage{{Role: "user", Type: MessageTypeText, Text: text}}, nil
+	}
+
+	renderer := &messageRenderer{prompt: prompt, arguments: arguments, funcs: funcs}
+	rendered := make([]*RenderedMessage, 0, len(prompt.Messages))
+	for index, message := range prompt.Messages {
+		renderedMessage, err := renderer.render(message)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %v", index+1, err)
+		}
+		rendered = append(rendered, renderedMessage)
+	}
+	return rendered, nil
+}
+
+type messageRenderer struct {
+	prompt    *PromptDefinition
+	arguments map[string]string
+	funcs     template.FuncMap
+}
+
+func (r *messageRenderer) renderText(text string) (string, error) {
+	return RenderTemplate(r.prompt.Name, PromptEngine(r.prompt), text, r.arguments, r.funcs)
+}
+
+func (r *messageRenderer) render(message *PromptMessageDefinition) (*RenderedMessage, error) {
+	rendered := &RenderedMessage{Role: message.Role}
+
+	switch {
+	case message.Image != nil:
+		path, data, err := r.readFile(message.Image.File)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Type = MessageTypeImage
+		rendered.Data = base64.StdEncoding.EncodeToString(data)
+		rendered.MimeType = message.Image.MimeType
+		if rendered.MimeType == "" {
+			rendered.MimeType = mimeTypeFromExtension(path, "application/octet-stream")
+		}
+
+	case message.Resource != nil:
+		rendered.Type = MessageTypeResource
+		uri, err := r.renderText(message.Resource.Uri)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Uri = uri
+		rendered.MimeType = message.Resource.MimeType
+
+		if message.Resource.File != "" {
+			path, data, err := r.readFile(message.Resource.File)
+			if err != nil {
+				return nil, err
+			}
+			if rendered.Uri == "" {
+				rendered.Uri, err = r.fileUri(path)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if utf8.Valid(data) {
+				rendered.Text = string(data)
+				if rendered.MimeType == "" {
+					rendered.MimeType = mimeTypeFromExtension(path, "text/plain")
+				}
+			} else {
+				rendered.Data = base64.StdEncoding.EncodeToString(data)
+				if rendered.MimeType == "" {
+					rendered.MimeType = mimeTypeFromExtension(path, "application/octet-stream")
+				}
+			}
+		} else {
+			text, err := r.renderText(message.Resource.Text)
+			if err != nil {
+				return nil, err
+			}
+			rendered.Text = text
+			if rendered.MimeType == "" {
+				rendered.MimeType = "text/plain"
+			}
+		}
+		if rendered.Uri == "" {
+			return nil, fmt.Errorf("the resource needs an uri")
+		}
+
+	default:
+		text, err := r.renderText(message.Text)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Type = MessageTypeText
+		rendered.Text = text
+	}
+
+	return rendered, nil
+}
+
+// readFile renders the path of the file and reads it, the path
+// is relative to the directory of the prompts file
+func (r *messageRenderer) readFile(pathTemplate string) (string, []byte, error) {
+	path, err := r.renderText(pathTemplate)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the arguments come from the client: they must not
+	// give access to files outside of the prompts directory
+	if path != pathTemplate && !filepath.IsLocal(path) {
+		return "", nil, fmt.Errorf("invalid file %s: the path must stay in the prompts directory", path)
+	}
+
+	if r.prompt.SourceFS != nil {
+		// the paths of a file system are slash separated
+		// and can't go above its root
+		name := pathpkg.Join(pathpkg.Dir(r.prompt.SourceFile), path)
+		if !fs.ValidPath(name) {
+			return "", nil, fmt.Errorf("invalid file %s: the path must stay in the prompts directory", path)
+		}
+		data, err := fs.ReadFile(r.prompt.SourceFS, name)
+		if err != nil {
+			return "", nil, err
+		}
+		return name, data, nil
+	}
+
+	if !filepath.IsAbs(path) && r.prompt.SourceFile != "" {
+		path = filepath.Join(filepath.Dir(r.prompt.SourceFile), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, data, nil
+}
+
+// fileUri returns the uri of a file returned by readFile
+func (r *messageRenderer) fileUri(path string) (string, error) {
+	// there is no absolute path in a file system like embed.FS
+	if r.prompt.SourceFS != nil {
+		return "file:///" + path, nil
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(absolutePath), nil
+}
+
+func mimeTypeFromExtension(path string, defaultMimeType string) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		return defaultMimeType
+	}
+	// remove the parameters, eg. "; charset=utf-8"
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return defaultMimeType
+	}
+	return mediaType
+}
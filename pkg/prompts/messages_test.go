@@ -0,0 +1,56 @@
+package prompts
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMessages(t *testing.T) {
+	list, err := LoadPromptYamlFile("testdata/messages/prompts.yaml")
+	if !assert.NoError(t, err) {
+		return
+	}
+	prompt := list.Prompts[0]
+
+	messages, err := RenderMessages(prompt, map[string]string{"audience": "a student", "diagram": "pixel.png"}, nil)
+	if !assert.NoError(t, err) || !assert.Len(t, messages, 4) {
+		return
+	}
+
+	assert.Equal(t, &RenderedMessage{Role: "user", Type: MessageTypeText, Text: "Explain this diagram to a student"}, messages[0])
+
+	image, _ := os.ReadFile("testdata/messages/pixel.png")
+	assert.Equal(t, MessageTypeImage, messages[1].Type)
+	assert.Equal(t, "image/png", messages[1].MimeType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(image), messages[1].Data)
+
+	assert.Equal(t, "assistant", messages[3].Role)
+	assert.Equal(t, MessageTypeResource, messages[3].Type)
+	assert.Equal(t, "text/x-go", messages[3].MimeType)
+	assert.True(t, strings.HasPrefix(messages[3].Uri, "file:///"))
+	assert.True(t, strings.HasSuffix(messages[3].Uri, "/testdata/messages/add.go.txt"))
+	assert.Contains(t, messages[3].Text, "return a + b")
+
+	// the arguments can't be used to read files outside of the prompts directory
+	_, err = RenderMessages(prompt, map[string]string{"audience": "a student", "diagram": "../valid.yaml"}, nil)
+	assert.ErrorContains(t, err, "the path must stay in the prompts directory")
+	_, err = RenderMessages(prompt, map[string]string{"audience": "a student", "diagram": "/etc/passwd"}, nil)
+	assert.ErrorContains(t, err, "the path must stay in the prompts directory")
+}
+
+func TestCheckPromptDefinition(t *testing.T) {
+	assert.ErrorContains(t, CheckPromptDefinition(&PromptDefinition{Name: "empty"}), "either prompt or messages must be set")
+	assert.ErrorContains(t, CheckPromptDefinition(&PromptDefinition{
+		Name:     "both",
+		Prompt:   "hello",
+		Messages: []*PromptMessageDefinition{{Role: "user", Text: "hello"}},
+	}), "can't be both set")
+	assert.ErrorContains(t, CheckPromptDefinition(&PromptDefinition{
+		Name:     "two contents",
+		Messages: []*PromptMessageDefinition{{Role: "user", Text: "hello", Image: &PromptImageDefinition{File: "a.png"}}},
+	}), "message 1: exactly one of text, image and resource must be set")
+}
@@ -0,0 +1,161 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadPromptFS loads the prompts files of fsys matching pattern, see fs.Glob.
+// A directory matching the pattern is walked to load all its .yaml, .yml and
+// .md files. A Markdown file defines a single prompt: the front-matter gives the
+// name (the name of the file by default), the description and the arguments,
+// the body is the template:
+//
+//	---
+//	description: Reviews a pull request
+//	arguments:
+//	  - name: diff
+//	    description: the diff to review
+//	    required: true
+//	---
+//	Review the following diff: {{.diff}}
+func LoadPromptFS(fsys fs.FS, pattern string) (*PromptList, error) {
+	filePaths, err := globPromptFiles(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	promptList := &PromptList{Prompts: []*PromptDefinition{}}
+	for _, filePath := range filePaths {
+		filePrompts, err := loadPromptFSFile(fsys, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filePath, err)
+		}
+		promptList.Prompts = append(promptList.Prompts, filePrompts.Prompts...)
+	}
+	return promptList, nil
+}
+
+func globPromptFiles(fsys fs.FS, pattern string) ([]string, error) {
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no prompts file matches %s", pattern)
+	}
+
+	found := make(map[string]bool)
+	for _, match := range matches {
+		info, err := fs.Stat(fsys, match)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			found[match] = true
+			continue
+		}
+		err = fs.WalkDir(fsys, match, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && isPromptFile(filePath) {
+				found[filePath] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// sorted so that the first of two duplicated prompts is always the same
+	filePaths := make([]string, 0, len(found))
+	for filePath := range found {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	return filePaths, nil
+}
+
+func isPromptFile(filePath string) bool {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".yaml", ".yml", ".md":
+		return true
+	}
+	return false
+}
+
+func loadPromptFSFile(fsys fs.FS, filePath string) (*PromptList, error) {
+	source, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if isMarkdownPromptFile(filePath) {
+		data, err = markdownPromptData(filePath, source)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := yaml.Unmarshal(source, &data); err != nil {
+		return nil, err
+	}
+
+	return decodePromptList(data, filePath, fsys)
+}
+
+// markdownPromptData converts a Markdown file with a front-matter
+// to the content of a prompts file with a single prompt
+func markdownPromptData(filePath string, source []byte) (interface{}, error) {
+	frontMatter, body, _, err := splitMarkdownPrompt(source)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := map[string]interface{}{}
+	if err := yaml.Unmarshal(frontMatter, &prompt); err != nil {
+		return nil, fmt.Errorf("invalid front-matter: %v", err)
+	}
+	if _, found := prompt["prompt"]; found {
+		return nil, fmt.Errorf("the front-matter can't have a prompt, the body of the file is the prompt")
+	}
+	if _, found := prompt["messages"]; found {
+		return nil, fmt.Errorf("the front-matter can't have messages, the body of the file is the prompt")
+	}
+	if _, found := prompt["name"]; !found {
+		prompt["name"] = strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	}
+	prompt["prompt"] = body
+
+	return map[string]interface{}{"prompts": []interface{}{prompt}}, nil
+}
+
+// splitMarkdownPrompt returns the front-matter and the body of a Markdown
+// prompt file, bodyLine is the line of the body in the file, starting at 1
+func splitMarkdownPrompt(source []byte) ([]byte, string, int, error) {
+	source = bytes.ReplaceAll(source, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(source, []byte("---\n")) {
+		return nil, "", 0, fmt.Errorf("missing front-matter, the file must start with ---")
+	}
+	frontMatter, rest, found := bytes.Cut(source[len("---\n"):], []byte("\n---\n"))
+	if !found {
+		return nil, "", 0, fmt.Errorf("the front-matter is not closed by ---")
+	}
+	body := strings.TrimLeft(string(rest), "\n")
+	// the opening ---, the front-matter, the closing --- and the skipped empty lines
+	bodyLine := 1 + bytes.Count(frontMatter, []byte("\n")) + 1 + 1 + 1 + len(rest) - len(body)
+	return frontMatter, body, bodyLine, nil
+}
+
+// isMarkdownPromptFile returns true for the prompts
+// written in Markdown with a front-matter
+func isMarkdownPromptFile(filePath string) bool {
+	return strings.ToLower(path.Ext(filePath)) == ".md"
+}
@@ -0,0 +1,78 @@
+package prompts
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var promptFS = fstest.MapFS{
+	"prompts/hello.yaml": &fstest.MapFile{Data: []byte(`prompts:
+  - name: hello
+    description: Says hello
+    prompt: Hello!
+`)},
+	"prompts/review/review.md": &fstest.MapFile{Data: []byte(`---
+description: Reviews a diff
+arguments:
+  - name: diff
+    description: the diff to review
+    required: true
+---
+
+Review the following diff:
+{{.diff}}
+`)},
+	"prompts/review/logo.yaml": &fstest.MapFile{Data: []byte(`prompts:
+  - name: logo
+    description: Describes the logo
+    messages:
+      - role: user
+        image:
+          file: images/logo.png
+`)},
+	"prompts/review/images/logo.png": &fstest.MapFile{Data: []byte("\x89PNG")},
+	"prompts/README.txt":             &fstest.MapFile{Data: []byte("not a prompt")},
+}
+
+func TestLoadPromptFS(t *testing.T) {
+	list, err := LoadPromptFS(promptFS, "prompts")
+	if !assert.NoError(t, err) || !assert.Len(t, list.Prompts, 3) {
+		return
+	}
+
+	// the files are sorted by path
+	assert.Equal(t, "hello", list.Prompts[0].Name)
+	assert.Equal(t, "logo", list.Prompts[1].Name)
+
+	review := list.Prompts[2]
+	assert.Equal(t, "review", review.Name)
+	assert.Equal(t, "prompts/review/review.md", review.SourceFile)
+	assert.Equal(t, "Reviews a diff", review.Description)
+	assert.True(t, review.Arguments[0].Required)
+	rendered, err := RenderPrompt(review, map[string]string{"diff": "+ added"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Review the following diff:\n+ added\n", rendered)
+
+	// the files of the messages are read from the file system
+	messages, err := RenderMessages(list.Prompts[1], map[string]string{}, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "image/png", messages[0].MimeType)
+		assert.Equal(t, "iVBORw==", messages[0].Data)
+	}
+
+	list, err = LoadPromptFS(promptFS, "prompts/*.yaml")
+	if assert.NoError(t, err) {
+		assert.Len(t, list.Prompts, 1)
+	}
+
+	_, err = LoadPromptFS(promptFS, "missing/*.yaml")
+	assert.ErrorContains(t, err, "no prompts file matches missing/*.yaml")
+}
+
+func TestLoadPromptFSInvalidMarkdown(t *testing.T) {
+	fsys := fstest.MapFS{"review.md": &fstest.MapFile{Data: []byte("Review {{.diff}}")}}
+	_, err := LoadPromptFS(fsys, "*.md")
+	assert.ErrorContains(t, err, "review.md: missing front-matter")
+}
@@ -3,6 +3,7 @@ package prompts
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"reflect"
 
@@ -17,18 +18,25 @@ func LoadPromptYamlFile(filepath string) (*PromptList, error) {
 		return nil, err
 	}
 
-	// retrieve the schema for the PromptConfig struct
-	configSchema, _, err := jsonschema.GetFullSchemaFromInterface(reflect.TypeOf(&PromptList{}))
-	if err != nil {
-		return nil, fmt.Errorf("error generating schema for PromptList")
-	}
-
 	// unmarshal the yaml data into an interface{}
 	var data interface{}
 	if err := yaml.Unmarshal(yamlData, &data); err != nil {
 		return nil, err
 	}
 
+	return decodePromptList(data, filepath, nil)
+}
+
+// decodePromptList validates the content of a prompts file against the schema
+// of PromptList, sourceFS is the file system the file was read from, nil for
+// the local file system
+func decodePromptList(data interface{}, sourceFile string, sourceFS fs.FS) (*PromptList, error) {
+	// retrieve the schema for the PromptConfig struct
+	configSchema, _, err := jsonschema.GetFullSchemaFromInterface(reflect.TypeOf(&PromptList{}))
+	if err != nil {
+		return nil, fmt.Errorf("error generating schema for PromptList")
+	}
+
 	// Convert to JSON
 	jsonData, err := json.MarshalIndent(data, "", "    ")
 	if err != nil {
@@ -47,5 +55,13 @@ func LoadPromptYamlFile(filepath string) (*PromptList, error) {
 		return nil, err
 	}
 
+	for _, prompt := range config.Prompts {
+		if err := CheckPromptDefinition(prompt); err != nil {
+			return nil, fmt.Errorf("prompt %s: %v", prompt.Name, err)
+		}
+		prompt.SourceFile = sourceFile
+		prompt.SourceFS = sourceFS
+	}
+
 	return &config, nil
 }
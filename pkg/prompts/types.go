@@ -1,5 +1,7 @@
 package prompts
 
+import "io/fs"
+
 // PromptList represents the root of a yaml file containing a list of prompts
 type PromptList struct {
 	Prompts []*PromptDefinition `json:"prompts" yaml:"prompts"`
@@ -9,13 +11,50 @@ type PromptDefinition struct {
 	Name        string                     `json:"name" yaml:"name"`
 	Description string                     `json:"description" yaml:"description"`
 	Arguments   []PromptArgumentDefinition `json:"arguments,omitempty" yaml:"arguments,omitempty"`
-	Prompt      string                     `json:"prompt" yaml:"prompt"`
+	// a single user message, either Prompt or Messages must be set
+	Prompt   string                     `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+	Messages []*PromptMessageDefinition `json:"messages,omitempty" yaml:"messages,omitempty"`
+	// text (the default), sprig or literal, see EngineText
+	Engine string `json:"engine,omitempty" yaml:"engine,omitempty" jsonschema:"enum=text,enum=sprig,enum=literal"`
+
+	// the file the prompt was loaded from, the relative
+	// paths of the messages are resolved from its directory
+	SourceFile string `json:"-" yaml:"-"`
+	// the file system of SourceFile, nil for the local file system
+	SourceFS fs.FS `json:"-" yaml:"-"`
 }
 
 type PromptArgumentDefinition struct {
 	Name        string `json:"name" yaml:"name"`
 	Description string `json:"description" yaml:"description"`
 	Required    bool   `json:"required" yaml:"required"`
+	// the values suggested to the client when it completes the argument
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// PromptMessageDefinition is a message of a multi-message prompt,
+// exactly one of Text, Image and Resource must be set
+type PromptMessageDefinition struct {
+	Role     string                    `json:"role" yaml:"role" jsonschema:"enum=user,enum=assistant"`
+	Text     string                    `json:"text,omitempty" yaml:"text,omitempty"`
+	Image    *PromptImageDefinition    `json:"image,omitempty" yaml:"image,omitempty"`
+	Resource *PromptResourceDefinition `json:"resource,omitempty" yaml:"resource,omitempty"`
+}
+
+type PromptImageDefinition struct {
+	File string `json:"file" yaml:"file"`
+	// guessed from the extension of the file if not set
+	MimeType string `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
+}
+
+// PromptResourceDefinition is an embedded resource, its content
+// is either read from File or given by Text
+type PromptResourceDefinition struct {
+	// file:// followed by the absolute path of File if not set
+	Uri      string `json:"uri,omitempty" yaml:"uri,omitempty"`
+	File     string `json:"file,omitempty" yaml:"file,omitempty"`
+	Text     string `json:"text,omitempty" yaml:"text,omitempty"`
+	MimeType string `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
 }
 
 type DuplicatedPrompt struct {
@@ -0,0 +1,511 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in a prompts file, the line and
+// column start at 1 and are 0 when the position is unknown
+type Issue struct {
+	FilePath string
+	Line     int
+	Column   int
+	Severity Severity
+	Message  string
+}
+
+func (i *Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", i.FilePath, i.Line, i.Column, i.Severity, i.Message)
+}
+
+var (
+	// eg. "yaml: line 3: mapping values are not allowed in this context"
+	yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	// eg. "template: summarize:2: unexpected "}" in operand"
+	templateErrorLine = regexp.MustCompile(`^template: [^:]*:(\d+): (.*)$`)
+)
+
+// promptLocation is the place where a prompt is defined
+type promptLocation struct {
+	filePath string
+	line     int
+}
+
+// ValidatePromptFiles checks the prompt files, YAML or Markdown with a front-matter,
+// without loading them in a registry:
+// the structure of the files, the syntax of the templates, the references to
+// undeclared arguments, the unused arguments and the duplicated names, also
+// across files. The error is only set if a file can't be read.
+func ValidatePromptFiles(filePaths ...string) ([]*Issue, error) {
+	issues := []*Issue{}
+	promptNames := make(map[string]*promptLocation)
+	for _, filePath := range filePaths {
+		source, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, validatePromptSource(filePath, source, promptNames)...)
+	}
+	return issues, nil
+}
+
+func validatePromptSource(filePath string, source []byte, promptNames map[string]*promptLocation) []*Issue {
+	v := &validator{
+		filePath: filePath,
+		lines:    strings.Split(string(source), "\n"),
+		issues:   []*Issue{},
+	}
+
+	var root *yaml.Node
+	if isMarkdownPromptFile(filePath) {
+		root = v.markdownRoot(source)
+	} else {
+		root = v.yamlRoot(source)
+	}
+	if root == nil {
+		return v.issues
+	}
+
+	// the structure must be valid before looking at the prompts
+	if !v.validateSchema(root) {
+		return v.issues
+	}
+	var promptList PromptList
+	if err := root.Decode(&promptList); err != nil {
+		v.addIssue(root.Line, root.Column, SeverityError, "%v", err)
+		return v.issues
+	}
+	promptNodes := findNode(root, []string{"prompts"})
+
+	for index, prompt := range promptList.Prompts {
+		promptNode := findNode(promptNodes, []string{strconv.Itoa(index)})
+		nameNode := findNode(promptNode, []string{"name"})
+
+		if previous, found := promptNames[prompt.Name]; found {
+			v.addIssue(nameNode.Line, nameNode.Column, SeverityError,
+				"duplicated prompt %s, already defined at %s:%d", prompt.Name, previous.filePath, previous.line)
+		} else {
+			promptNames[prompt.Name] = &promptLocation{filePath: filePath, line: nameNode.Line}
+		}
+
+		v.validatePrompt(prompt, promptNode)
+	}
+
+	return v.issues
+}
+
+// yamlRoot parses a prompts file, nil is returned if it is invalid
+func (v *validator) yamlRoot(source []byte) *yaml.Node {
+	var document yaml.Node
+	if err := yaml.Unmarshal(source, &document); err != nil {
+		v.addYamlIssue(err, 0, "invalid YAML")
+		return nil
+	}
+	if len(document.Content) == 0 {
+		v.addIssue(1, 1, SeverityError, "empty file")
+		return nil
+	}
+	return document.Content[0]
+}
+
+// markdownRoot builds the document of a prompts file with the prompt of a
+// Markdown file: the front-matter with the body as prompt, the positions
+// of the nodes are the ones in the Markdown file. nil is returned if the
+// file is invalid
+func (v *validator) markdownRoot(source []byte) *yaml.Node {
+	frontMatter, body, bodyLine, err := splitMarkdownPrompt(source)
+	if err != nil {
+		v.addIssue(1, 1, SeverityError, "%v", err)
+		return nil
+	}
+
+	// the front-matter starts on the second line
+	prompt := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 2, Column: 1}
+	var document yaml.Node
+	if err := yaml.Unmarshal(frontMatter, &document); err != nil {
+		v.addYamlIssue(err, 1, "invalid front-matter")
+		return nil
+	}
+	if len(document.Content) > 0 {
+		prompt = document.Content[0]
+		shiftLines(prompt, 1)
+	}
+	if prompt.Kind != yaml.MappingNode {
+		v.addIssue(prompt.Line, prompt.Column, SeverityError, "invalid front-matter: expected a mapping")
+		return nil
+	}
+	for _, key := range []string{"prompt", "messages"} {
+		if node := findNode(prompt, []string{key}); node != prompt {
+			v.addIssue(node.Line, node.Column, SeverityError,
+				"the front-matter can't have %s, the body of the file is the prompt", key)
+			return nil
+		}
+	}
+
+	if findNode(prompt, []string{"name"}) == prompt {
+		name := strings.TrimSuffix(filepath.Base(v.filePath), filepath.Ext(v.filePath))
+		prompt.Content = append(prompt.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "name", Line: 1, Column: 1},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name, Line: 1, Column: 1})
+	}
+	// like a block scalar, the body starts on the line after the node
+	prompt.Content = append(prompt.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "prompt", Line: bodyLine, Column: 1},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: body, Style: yaml.LiteralStyle, Line: bodyLine - 1, Column: 1})
+
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1, Column: 1, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "prompts", Line: 1, Column: 1},
+		{Kind: yaml.SequenceNode, Tag: "!!seq", Line: 1, Column: 1, Content: []*yaml.Node{prompt}},
+	}}
+}
+
+// addYamlIssue reports a YAML error, offset is the line
+// of the YAML content in the file minus 1
+func (v *validator) addYamlIssue(err error, offset int, prefix string) {
+	line := 0
+	message := err.Error()
+	if match := yamlErrorLine.FindStringSubmatch(message); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		line += offset
+		message = match[2]
+	}
+	v.addIssue(line, 0, SeverityError, "%s: %s", prefix, message)
+}
+
+func shiftLines(node *yaml.Node, offset int) {
+	node.Line += offset
+	for _, child := range node.Content {
+		shiftLines(child, offset)
+	}
+}
+
+type validator struct {
+	filePath string
+	lines    []string
+	issues   []*Issue
+}
+
+func (v *validator) addIssue(line int, column int, severity Severity, format string, args ...interface{}) {
+	v.issues = append(v.issues, &Issue{
+		FilePath: v.filePath,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// validateSchema checks the document against the schema of PromptList
+func (v *validator) validateSchema(root *yaml.Node) bool {
+	configSchema, _, err := jsonschema.GetFullSchemaFromInterface(reflect.TypeOf(&PromptList{}))
+	if err != nil {
+		v.addIssue(0, 0, SeverityError, "error generating schema for PromptList")
+		return false
+	}
+
+	var data interface{}
+	if err := root.Decode(&data); err != nil {
+		v.addIssue(root.Line, root.Column, SeverityError, "%v", err)
+		return false
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		v.addIssue(root.Line, root.Column, SeverityError, "error converting to JSON: %v", err)
+		return false
+	}
+
+	validationErrors, err := jsonschema.GetValidationErrors(configSchema, jsonData)
+	if err != nil {
+		v.addIssue(root.Line, root.Column, SeverityError, "%v", err)
+		return false
+	}
+	for _, validationError := range validationErrors {
+		path := []string{}
+		if validationError.Field != "" {
+			path = strings.Split(validationError.Field, ".")
+		}
+		node := findNode(root, path)
+		message := validationError.Message
+		if validationError.Field != "" {
+			message = validationError.Field + ": " + message
+		}
+		v.addIssue(node.Line, node.Column, SeverityError, "%s", message)
+	}
+	return len(validationErrors) == 0
+}
+
+func (v *validator) validatePrompt(prompt *PromptDefinition, promptNode *yaml.Node) {
+	// the declared arguments, with the position of their name
+	declared := make(map[string]*yaml.Node)
+	argumentsNode := findNode(promptNode, []string{"arguments"})
+	for index, argument := range prompt.Arguments {
+		nameNode := findNode(argumentsNode, []string{strconv.Itoa(index), "name"})
+		if _, found := declared[argument.Name]; found {
+			v.addIssue(nameNode.Line, nameNode.Column, SeverityError,
+				"prompt %s: duplicated argument %s", prompt.Name, argument.Name)
+			continue
+		}
+		declared[argument.Name] = nameNode
+	}
+
+	if err := CheckPromptDefinition(prompt); err != nil {
+		v.addIssue(promptNode.Line, promptNode.Column, SeverityError, "prompt %s: %v", prompt.Name, err)
+		return
+	}
+
+	if PromptEngine(prompt) == EngineLiteral {
+		for _, argument := range prompt.Arguments {
+			v.addIssue(declared[argument.Name].Line, declared[argument.Name].Column, SeverityWarning,
+				"prompt %s: argument %s is not used, the literal engine ignores the arguments", prompt.Name, argument.Name)
+		}
+		return
+	}
+
+	references := &argumentReferences{used: make(map[string]bool)}
+	valid := true
+	for _, promptTemplate := range promptTemplates(prompt, promptNode) {
+		if !v.validateTemplate(prompt, promptTemplate.text, promptTemplate.node, declared, references) {
+			valid = false
+		}
+	}
+
+	// the template may use the arguments without naming them, eg. {{range $k, $v := .}}
+	if !valid || references.usesDot {
+		return
+	}
+	for _, argument := range prompt.Arguments {
+		if references.used[argument.Name] {
+			continue
+		}
+		nameNode := declared[argument.Name]
+		v.addIssue(nameNode.Line, nameNode.Column, SeverityWarning,
+			"prompt %s: argument %s is not used in the template", prompt.Name, argument.Name)
+	}
+}
+
+type promptTemplate struct {
+	text string
+	node *yaml.Node
+}
+
+// promptTemplates returns all the fields of the prompt rendered with the arguments
+func promptTemplates(prompt *PromptDefinition, promptNode *yaml.Node) []*promptTemplate {
+	if len(prompt.Messages) == 0 {
+		return []*promptTemplate{{text: prompt.Prompt, node: findNode(promptNode, []string{"prompt"})}}
+	}
+
+	templates := []*promptTemplate{}
+	add := func(text string, path ...string) {
+		if text != "" {
+			templates = append(templates, &promptTemplate{text: text, node: findNode(promptNode, path)})
+		}
+	}
+	for index, message := range prompt.Messages {
+		i := strconv.Itoa(index)
+		add(message.Text, "messages", i, "text")
+		if message.Image != nil {
+			add(message.Image.File, "messages", i, "image", "file")
+		}
+		if message.Resource != nil {
+			add(message.Resource.Uri, "messages", i, "resource", "uri")
+			add(message.Resource.File, "messages", i, "resource", "file")
+			add(message.Resource.Text, "messages", i, "resource", "text")
+		}
+	}
+	return templates
+}
+
+// validateTemplate parses a template of the prompt and reports the references
+// to undeclared arguments, false is returned if the template is invalid
+func (v *validator) validateTemplate(prompt *PromptDefinition, text string, templateNode *yaml.Node, declared map[string]*yaml.Node, references *argumentReferences) bool {
+	// the functions are not checked: the server may add its own to the registry
+	trees := make(map[string]*parse.Tree)
+	tree := parse.New(prompt.Name)
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		message := err.Error()
+		line, column := templateNode.Line, templateNode.Column
+		if match := templateErrorLine.FindStringSubmatch(message); match != nil {
+			templateLine, _ := strconv.Atoi(match[1])
+			line, column = v.templatePosition(templateNode, templateLine-1, 0)
+			message = match[2]
+		}
+		v.addIssue(line, column, SeverityError, "prompt %s: invalid template: %s", prompt.Name, message)
+		return false
+	}
+
+	// sorted to report the issues in a stable order
+	names := make([]string, 0, len(trees))
+	for name := range trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		references.collect(trees[name].Root, true, func(name string, pos parse.Pos) {
+			if _, found := declared[name]; found {
+				return
+			}
+			templateLine, templateColumn := offsetToLineColumn(text, int(pos))
+			line, column := v.templatePosition(templateNode, templateLine, templateColumn)
+			v.addIssue(line, column, SeverityError,
+				"prompt %s: reference to undeclared argument %s", prompt.Name, name)
+		})
+	}
+	return true
+}
+
+// templatePosition converts a position in the template, starting at 0,
+// to a position in the file
+func (v *validator) templatePosition(node *yaml.Node, templateLine int, templateColumn int) (int, int) {
+	switch node.Style {
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		// the content of a block scalar starts on the next line
+		line := node.Line + 1 + templateLine
+		if line-1 >= len(v.lines) {
+			return node.Line, node.Column
+		}
+		text := v.lines[line-1]
+		indentation := len(text) - len(strings.TrimLeft(text, " "))
+		return line, indentation + templateColumn + 1
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		if templateLine == 0 {
+			// skip the quote
+			return node.Line, node.Column + templateColumn + 1
+		}
+		return node.Line + templateLine, 0
+	default:
+		if templateLine == 0 {
+			return node.Line, node.Column + templateColumn
+		}
+		return node.Line + templateLine, 0
+	}
+}
+
+func offsetToLineColumn(text string, offset int) (int, int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	before := text[:offset]
+	line := strings.Count(before, "\n")
+	column := offset - (strings.LastIndex(before, "\n") + 1)
+	return line, column
+}
+
+// argumentReferences collects the arguments used by a template
+type argumentReferences struct {
+	used    map[string]bool
+	usesDot bool
+}
+
+// collect walks the template tree, dotIsRoot is false in the body of range
+// and with where the dot is no longer the map of the arguments
+func (r *argumentReferences) collect(node parse.Node, dotIsRoot bool, visit func(name string, pos parse.Pos)) {
+	switch n := node.(type) {
+	case nil:
+		return
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			r.collect(child, dotIsRoot, visit)
+		}
+	case *parse.ActionNode:
+		r.collect(n.Pipe, dotIsRoot, visit)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, command := range n.Cmds {
+			r.collect(command, dotIsRoot, visit)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			r.collect(arg, dotIsRoot, visit)
+		}
+	case *parse.ChainNode:
+		r.collect(n.Node, dotIsRoot, visit)
+	case *parse.FieldNode:
+		if dotIsRoot {
+			r.use(n.Ident[0], n.Pos, visit)
+		}
+	case *parse.VariableNode:
+		// $ is always the map of the arguments
+		if n.Ident[0] == "$" {
+			if len(n.Ident) > 1 {
+				r.use(n.Ident[1], n.Pos, visit)
+			} else {
+				r.usesDot = true
+			}
+		}
+	case *parse.DotNode:
+		if dotIsRoot {
+			r.usesDot = true
+		}
+	case *parse.IfNode:
+		r.collect(n.Pipe, dotIsRoot, visit)
+		r.collect(n.List, dotIsRoot, visit)
+		r.collect(n.ElseList, dotIsRoot, visit)
+	case *parse.RangeNode:
+		r.collect(n.Pipe, dotIsRoot, visit)
+		r.collect(n.List, false, visit)
+		r.collect(n.ElseList, dotIsRoot, visit)
+	case *parse.WithNode:
+		r.collect(n.Pipe, dotIsRoot, visit)
+		r.collect(n.List, false, visit)
+		r.collect(n.ElseList, dotIsRoot, visit)
+	case *parse.TemplateNode:
+		r.collect(n.Pipe, dotIsRoot, visit)
+	}
+}
+
+func (r *argumentReferences) use(name string, pos parse.Pos, visit func(name string, pos parse.Pos)) {
+	r.used[name] = true
+	visit(name, pos)
+}
+
+// findNode follows the path of mapping keys and sequence indexes,
+// the last node found is returned if the path does not exist
+func findNode(node *yaml.Node, path []string) *yaml.Node {
+	for _, segment := range path {
+		var child *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					child = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segment)
+			if err == nil && index >= 0 && index < len(node.Content) {
+				child = node.Content[index]
+			}
+		}
+		if child == nil {
+			return node
+		}
+		node = child
+	}
+	return node
+}
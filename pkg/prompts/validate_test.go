@@ -0,0 +1,57 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePromptFiles(t *testing.T) {
+	issues, err := ValidatePromptFiles("testdata/valid.yaml")
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+
+	issues, err = ValidatePromptFiles("testdata/valid.yaml", "testdata/invalid.yaml")
+	assert.NoError(t, err)
+
+	messages := []string{}
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Equal(t, []string{
+		"testdata/invalid.yaml:8:15: error: prompt translate: duplicated argument text",
+		"testdata/invalid.yaml:12:41: error: prompt translate: reference to undeclared argument language",
+		"testdata/invalid.yaml:23:14: error: prompt review: invalid template: bad character U+007D '}'",
+		"testdata/invalid.yaml:30:15: warning: prompt explain: argument audience is not used in the template",
+		"testdata/invalid.yaml:34:11: error: duplicated prompt summarize, already defined at testdata/valid.yaml:2",
+	}, messages)
+}
+
+func TestValidatePromptFilesSchema(t *testing.T) {
+	issues, err := ValidatePromptFiles("testdata/invalid_schema.yaml")
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, 3, issues[0].Line)
+	assert.Contains(t, issues[0].Message, "prompts.0")
+}
+
+func TestValidateMarkdownPromptFiles(t *testing.T) {
+	issues, err := ValidatePromptFiles("testdata/review.md")
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+
+	issues, err = ValidatePromptFiles("testdata/invalid.md", "testdata/broken.md", "testdata/broken_template.md")
+	assert.NoError(t, err)
+
+	// the positions are the ones in the Markdown file
+	messages := []string{}
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Equal(t, []string{
+		"testdata/invalid.md:14:13: error: prompt invalid: reference to undeclared argument reader",
+		"testdata/invalid.md:7:11: warning: prompt invalid: argument audience is not used in the template",
+		"testdata/broken.md:4:0: error: invalid front-matter: mapping values are not allowed in this context",
+		"testdata/broken_template.md:5:1: error: prompt broken_template: invalid template: bad character U+007D '}'",
+	}, messages)
+}
@@ -0,0 +1,221 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// a reference is either ${ENV_VAR} or ${file:/path/to/secret}
+// use $${...} to keep a literal ${...} in a value
+var referenceRegexp = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+
+const filePrefix = "file:"
+
+// InterpolateString replaces the ${ENV_VAR} references with the value of the
+// environment variable and the ${file:/path} references with the content of
+// the file, without its trailing newline
+func InterpolateString(value string) (string, error) {
+	var firstErr error
+	result := referenceRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		// escaped reference
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		reference := match[2 : len(match)-1]
+		resolved, err := resolveReference(reference)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return resolved
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return result, nil
+}
+
+func resolveReference(reference string) (string, error) {
+	if reference == "" {
+		return "", fmt.Errorf("empty reference ${}")
+	}
+	if strings.HasPrefix(reference, filePrefix) {
+		filePath := strings.TrimPrefix(reference, filePrefix)
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %v", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	value, ok := os.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", reference)
+	}
+	return value, nil
+}
+
+// InterpolateStruct interpolates in place all the exported string fields
+// of the struct pointed to by data, including nested structs, slices and maps
+func InterpolateStruct(data interface{}) error {
+	if data == nil {
+		return nil
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf("interpolation requires a pointer, got %T", data)
+	}
+	return interpolateValue(value, "")
+}
+
+// InterpolateCopy returns a deep copy of data, a pointer, with the references
+// interpolated like InterpolateStruct does. data is left untouched.
+func InterpolateCopy(data interface{}) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("interpolation requires a pointer, got %T", data)
+	}
+	copied := copyValue(value)
+	err := interpolateValue(copied, "")
+	if err != nil {
+		return nil, err
+	}
+	return copied.Interface(), nil
+}
+
+// copyValue copies the pointers, slices and maps so that the
+// interpolation of the copy does not change the original value
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		if v.Kind() == reflect.Interface {
+			copied := reflect.New(v.Type()).Elem()
+			copied.Set(copyValue(v.Elem()))
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(copyValue(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				copied.Field(i).Set(copyValue(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return copied
+	}
+	return v
+}
+
+func interpolateValue(value reflect.Value, path string) error {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		if value.Kind() == reflect.Interface {
+			// the value stored in an interface is not addressable
+			// we interpolate a copy and store it back
+			elem := value.Elem()
+			if elem.Kind() == reflect.String && value.CanSet() {
+				interpolated, err := interpolateField(elem.String(), path)
+				if err != nil {
+					return err
+				}
+				value.Set(reflect.ValueOf(interpolated))
+				return nil
+			}
+		}
+		return interpolateValue(value.Elem(), path)
+	case reflect.Struct:
+		valueType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			if !valueType.Field(i).IsExported() {
+				continue
+			}
+			err := interpolateValue(value.Field(i), joinPath(path, valueType.Field(i).Name))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			err := interpolateValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			elemPath := fmt.Sprintf("%s[%v]", path, iter.Key())
+			// map values are not addressable, we work on a copy
+			elem := reflect.New(value.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			err := interpolateValue(elem, elemPath)
+			if err != nil {
+				return err
+			}
+			value.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		if !value.CanSet() {
+			return nil
+		}
+		interpolated, err := interpolateField(value.String(), path)
+		if err != nil {
+			return err
+		}
+		value.SetString(interpolated)
+	}
+	return nil
+}
+
+func interpolateField(value string, path string) (string, error) {
+	interpolated, err := InterpolateString(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", path, err)
+	}
+	return interpolated, nil
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
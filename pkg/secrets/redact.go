@@ -0,0 +1,213 @@
+package secrets
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagName is the struct tag used to mark a field as a secret:
+//
+//	NotionToken string `json:"notionToken" gomcp:"secret"`
+const TagName = "gomcp"
+
+const RedactedValue = "[REDACTED]"
+
+// Redact returns a copy of value where the fields tagged as secret are
+// replaced by RedactedValue (string fields) or their zero value.
+// The value is returned as is if it does not hold any secret, the values
+// held by interfaces, eg. in a types.LogArg, are checked one by one.
+func Redact(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if !hasSecret(v, map[visit]bool{}) {
+		return value
+	}
+	return redactValue(v, map[visit]reflect.Value{}).Interface()
+}
+
+// IsSecretField returns true if the struct field is tagged as a secret
+func IsSecretField(field reflect.StructField) bool {
+	tag, ok := field.Tag.Lookup(TagName)
+	if !ok {
+		return false
+	}
+	for _, option := range strings.Split(tag, ",") {
+		if strings.TrimSpace(option) == "secret" {
+			return true
+		}
+	}
+	return false
+}
+
+// visit identifies a pointer or a map already seen, the type is part of the
+// key because a struct and its first field share the same address
+type visit struct {
+	pointer uintptr
+	t       reflect.Type
+}
+
+// containsSecret checks if a type has a secret field, either directly or
+// through nested types. visited prevents infinite recursion on recursive types.
+// The values held by interfaces are not known, see hasSecret.
+func containsSecret(t reflect.Type, visited map[reflect.Type]bool) bool {
+	return walkType(t, visited, false)
+}
+
+// mayContainSecret checks if a type has a secret field or an interface,
+// the values of the types without any can't hold a secret
+func mayContainSecret(t reflect.Type, visited map[reflect.Type]bool) bool {
+	return walkType(t, visited, true)
+}
+
+func walkType(t reflect.Type, visited map[reflect.Type]bool, withInterfaces bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return withInterfaces
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return walkType(t.Elem(), visited, withInterfaces)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if IsSecretField(field) || walkType(field.Type, visited, withInterfaces) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSecret checks if a value holds a secret field, the values held
+// by interfaces are only walked when their type may contain one
+func hasSecret(v reflect.Value, visited map[visit]bool) bool {
+	if !mayContainSecret(v.Type(), map[reflect.Type]bool{}) {
+		return false
+	}
+	if containsSecret(v.Type(), map[reflect.Type]bool{}) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return !v.IsNil() && hasSecret(v.Elem(), visited)
+	case reflect.Ptr:
+		if v.IsNil() || visited[visit{v.Pointer(), v.Type()}] {
+			return false
+		}
+		visited[visit{v.Pointer(), v.Type()}] = true
+		return hasSecret(v.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && hasSecret(v.Field(i), visited) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if hasSecret(v.Index(i), visited) {
+				return true
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() || visited[visit{v.Pointer(), v.Type()}] {
+			return false
+		}
+		visited[visit{v.Pointer(), v.Type()}] = true
+		iter := v.MapRange()
+		for iter.Next() {
+			if hasSecret(iter.Value(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactValue copies the parts of v holding a secret. copies maps the
+// pointers and maps already copied to their copy, so that a cyclic
+// value is copied once and keeps its cycles
+func redactValue(v reflect.Value, copies map[visit]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() || !hasSecret(v.Elem(), map[visit]bool{}) {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(redactValue(v.Elem(), copies))
+		return copied
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := visit{v.Pointer(), v.Type()}
+		if copied, found := copies[key]; found {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		copies[key] = copied
+		copied.Elem().Set(redactValue(v.Elem(), copies))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if IsSecretField(field) {
+				copied.Field(i).Set(redactedFieldValue(field.Type))
+			} else if hasSecret(v.Field(i), map[visit]bool{}) {
+				copied.Field(i).Set(redactValue(v.Field(i), copies))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactValue(v.Index(i), copies))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactValue(v.Index(i), copies))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := visit{v.Pointer(), v.Type()}
+		if copied, found := copies[key]; found {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		copies[key] = copied
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), redactValue(iter.Value(), copies))
+		}
+		return copied
+	}
+	return v
+}
+
+func redactedFieldValue(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.String {
+		return reflect.ValueOf(RedactedValue).Convert(t)
+	}
+	return reflect.Zero(t)
+}
@@ -0,0 +1,157 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type notionConfiguration struct {
+	NotionToken string            `json:"notionToken" gomcp:"secret"`
+	Workspace   string            `json:"workspace"`
+	Headers     map[string]string `json:"headers"`
+	Proxy       *proxyConfiguration
+}
+
+type proxyConfiguration struct {
+	Url      string
+	Password string `gomcp:"secret"`
+}
+
+func TestInterpolateString(t *testing.T) {
+	t.Setenv("GOMCP_TEST_TOKEN", "ntn_123")
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("s3cr3t\n"), 0600))
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "no reference", value: "plain", want: "plain"},
+		{name: "environment variable", value: "${GOMCP_TEST_TOKEN}", want: "ntn_123"},
+		{name: "embedded reference", value: "Bearer ${GOMCP_TEST_TOKEN}!", want: "Bearer ntn_123!"},
+		{name: "secret file", value: "${file:" + secretFile + "}", want: "s3cr3t"},
+		{name: "escaped reference", value: "$${GOMCP_TEST_TOKEN}", want: "${GOMCP_TEST_TOKEN}"},
+		{name: "missing variable", value: "${GOMCP_TEST_MISSING}", wantErr: true},
+		{name: "missing file", value: "${file:/does/not/exist}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateString(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInterpolateStruct(t *testing.T) {
+	t.Setenv("GOMCP_TEST_TOKEN", "ntn_123")
+	t.Setenv("GOMCP_TEST_PASSWORD", "pwd")
+
+	config := &notionConfiguration{
+		NotionToken: "${GOMCP_TEST_TOKEN}",
+		Headers:     map[string]string{"Authorization": "Bearer ${GOMCP_TEST_TOKEN}"},
+		Proxy:       &proxyConfiguration{Password: "${GOMCP_TEST_PASSWORD}"},
+	}
+	assert.NoError(t, InterpolateStruct(config))
+	assert.Equal(t, "ntn_123", config.NotionToken)
+	assert.Equal(t, "Bearer ntn_123", config.Headers["Authorization"])
+	assert.Equal(t, "pwd", config.Proxy.Password)
+
+	err := InterpolateStruct(&notionConfiguration{Workspace: "${GOMCP_TEST_MISSING}"})
+	assert.ErrorContains(t, err, "Workspace")
+}
+
+func TestInterpolateCopy(t *testing.T) {
+	t.Setenv("GOMCP_TEST_TOKEN", "ntn_123")
+
+	config := &notionConfiguration{
+		NotionToken: "${GOMCP_TEST_TOKEN}",
+		Headers:     map[string]string{"Authorization": "Bearer ${GOMCP_TEST_TOKEN}"},
+		Proxy:       &proxyConfiguration{Password: "${GOMCP_TEST_TOKEN}"},
+	}
+	copied, err := InterpolateCopy(config)
+	assert.NoError(t, err)
+	interpolated := copied.(*notionConfiguration)
+	assert.Equal(t, "ntn_123", interpolated.NotionToken)
+	assert.Equal(t, "Bearer ntn_123", interpolated.Headers["Authorization"])
+	assert.Equal(t, "ntn_123", interpolated.Proxy.Password)
+
+	// the references of the original value are kept
+	assert.Equal(t, "${GOMCP_TEST_TOKEN}", config.NotionToken)
+	assert.Equal(t, "Bearer ${GOMCP_TEST_TOKEN}", config.Headers["Authorization"])
+	assert.Equal(t, "${GOMCP_TEST_TOKEN}", config.Proxy.Password)
+}
+
+func TestRedact(t *testing.T) {
+	config := &notionConfiguration{
+		NotionToken: "ntn_123",
+		Workspace:   "acme",
+		Proxy:       &proxyConfiguration{Url: "http://proxy", Password: "pwd"},
+	}
+
+	redacted, ok := Redact(config).(*notionConfiguration)
+	assert.True(t, ok)
+	assert.Equal(t, RedactedValue, redacted.NotionToken)
+	assert.Equal(t, "acme", redacted.Workspace)
+	assert.Equal(t, RedactedValue, redacted.Proxy.Password)
+	assert.Equal(t, "http://proxy", redacted.Proxy.Url)
+
+	// the original value is untouched
+	assert.Equal(t, "ntn_123", config.NotionToken)
+	assert.Equal(t, "pwd", config.Proxy.Password)
+
+	// values without secrets are returned as is
+	assert.Equal(t, "hello", Redact("hello"))
+}
+
+func TestRedactInterface(t *testing.T) {
+	config := &notionConfiguration{NotionToken: "ntn_123", Workspace: "acme"}
+
+	// the secrets are found in the values held by an interface
+	fields := map[string]interface{}{"config": config, "count": 2}
+	redacted := Redact(fields).(map[string]interface{})
+	assert.Equal(t, RedactedValue, redacted["config"].(*notionConfiguration).NotionToken)
+	assert.Equal(t, "acme", redacted["config"].(*notionConfiguration).Workspace)
+	assert.Equal(t, 2, redacted["count"])
+
+	wrapped := Redact(struct{ Result any }{Result: config}).(struct{ Result any })
+	assert.Equal(t, RedactedValue, wrapped.Result.(*notionConfiguration).NotionToken)
+	assert.Equal(t, "ntn_123", config.NotionToken)
+}
+
+type linkedConfiguration struct {
+	Name  string
+	Token string `gomcp:"secret"`
+	Next  *linkedConfiguration
+	Extra any
+}
+
+func TestRedactCycles(t *testing.T) {
+	config := &linkedConfiguration{Name: "first", Token: "tok_1"}
+	config.Next = &linkedConfiguration{Name: "second", Token: "tok_2", Next: config}
+	config.Extra = config
+
+	redacted := Redact(config).(*linkedConfiguration)
+	assert.Equal(t, RedactedValue, redacted.Token)
+	assert.Equal(t, RedactedValue, redacted.Next.Token)
+	// the copy keeps the cycles
+	assert.Same(t, redacted, redacted.Next.Next)
+	assert.Same(t, redacted, redacted.Extra)
+	assert.Equal(t, "tok_1", config.Token)
+
+	// a cyclic value without secret is returned as is
+	fields := map[string]interface{}{"count": 1}
+	fields["self"] = fields
+	assert.Equal(t, reflect.ValueOf(fields).Pointer(), reflect.ValueOf(Redact(fields)).Pointer())
+}
@@ -0,0 +1,80 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// PrettyPrint writes the entries in a human readable form:
+// a summary line per message followed by the indented JSON message
+func PrettyPrint(w io.Writer, entries []*Entry) error {
+	for _, entry := range entries {
+		arrow := ">>"
+		if entry.Direction == DirectionOutbound {
+			arrow = "<<"
+		}
+		_, err := fmt.Fprintf(w, "%s [%s] %s %s %s\n",
+			entry.Timestamp.Format(time.RFC3339Nano), entry.SessionId, arrow, entry.Direction, Summary(entry))
+		if err != nil {
+			return err
+		}
+
+		var body string
+		if entry.Message != nil {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, entry.Message, "  ", "  "); err != nil {
+				body = string(entry.Message)
+			} else {
+				body = indented.String()
+			}
+		} else {
+			body = entry.Raw
+		}
+		if _, err := fmt.Fprintf(w, "  %s\n", body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Summary describes the message in a few words, eg. "request tools/call id=1"
+func Summary(entry *Entry) string {
+	if entry.Message == nil {
+		return "invalid message"
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(entry.Message, &batch); err == nil {
+		return fmt.Sprintf("batch of %d messages", len(batch))
+	}
+
+	var message struct {
+		Method *string          `json:"method"`
+		Id     *json.RawMessage `json:"id"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(entry.Message, &message); err != nil {
+		return "invalid message"
+	}
+
+	id := "null"
+	if message.Id != nil {
+		id = string(*message.Id)
+	}
+	switch {
+	case message.Method != nil && message.Id != nil:
+		return fmt.Sprintf("request %s id=%s", *message.Method, id)
+	case message.Method != nil:
+		return fmt.Sprintf("notification %s", *message.Method)
+	case message.Error != nil:
+		return fmt.Sprintf("error response id=%s: %d %s", id, message.Error.Code, message.Error.Message)
+	default:
+		return fmt.Sprintf("response id=%s", id)
+	}
+}
@@ -0,0 +1,26 @@
+package trace
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Direction string
+
+const (
+	// message received by the transport
+	DirectionInbound Direction = "in"
+	// message sent by the transport
+	DirectionOutbound Direction = "out"
+)
+
+// Entry is a line of a JSONL trace file
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Direction Direction `json:"direction"`
+	SessionId string    `json:"sessionId"`
+	// the JSON-RPC message, as received or sent
+	Message json.RawMessage `json:"message,omitempty"`
+	// the raw content when the message is not valid JSON
+	Raw string `json:"raw,omitempty"`
+}
@@ -1,5 +1,63 @@
 package mcp
 
+// the revisions of the specification supported by gomcp
+// https://modelcontextprotocol.io/specification/versioning
 const (
-	ProtocolVersion = "2024-11-05"
+	ProtocolVersion20241105 = "2024-11-05"
+	ProtocolVersion20250326 = "2025-03-26"
+	ProtocolVersion20250618 = "2025-06-18"
+
+	// ProtocolVersion is the latest version supported
+	ProtocolVersion = ProtocolVersion20250618
 )
+
+// SupportedProtocolVersions lists the supported versions, from the oldest to the latest
+var SupportedProtocolVersions = []string{
+	ProtocolVersion20241105,
+	ProtocolVersion20250326,
+	ProtocolVersion20250618,
+}
+
+func IsSupportedProtocolVersion(version string) bool {
+	for _, supported := range SupportedProtocolVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateProtocolVersion returns the version the server answers to the
+// version requested by the client: the same version if it is supported,
+// the latest supported version otherwise, the client then decides whether
+// it can use it
+func NegotiateProtocolVersion(requested string) string {
+	if IsSupportedProtocolVersion(requested) {
+		return requested
+	}
+	return ProtocolVersion
+}
+
+// ProtocolFeatures are the features that depend on the negotiated
+// version, they must not be sent to a client using an older version
+type ProtocolFeatures struct {
+	// the completions server capability
+	Completions bool
+	// the audio content of tool results
+	AudioContent bool
+	// the structuredContent of tool results
+	StructuredOutput bool
+	// the elicitation/create request
+	Elicitation bool
+}
+
+// FeaturesOf returns the features of a supported protocol version
+func FeaturesOf(version string) ProtocolFeatures {
+	// the versions are dates, they can be compared as strings
+	return ProtocolFeatures{
+		Completions:      version >= ProtocolVersion20250326,
+		AudioContent:     version >= ProtocolVersion20250326,
+		StructuredOutput: version >= ProtocolVersion20250618,
+		Elicitation:      version >= ProtocolVersion20250618,
+	}
+}
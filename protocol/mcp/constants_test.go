@@ -0,0 +1,22 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	assert.Equal(t, ProtocolVersion20241105, NegotiateProtocolVersion(ProtocolVersion20241105))
+	assert.Equal(t, ProtocolVersion20250326, NegotiateProtocolVersion(ProtocolVersion20250326))
+	assert.Equal(t, ProtocolVersion20250618, NegotiateProtocolVersion(ProtocolVersion20250618))
+	// unknown versions, older or newer, get the latest version
+	assert.Equal(t, ProtocolVersion, NegotiateProtocolVersion("2024-10-07"))
+	assert.Equal(t, ProtocolVersion, NegotiateProtocolVersion("2099-01-01"))
+}
+
+func TestFeaturesOf(t *testing.T) {
+	assert.Equal(t, ProtocolFeatures{}, FeaturesOf(ProtocolVersion20241105))
+	assert.Equal(t, ProtocolFeatures{Completions: true, AudioContent: true}, FeaturesOf(ProtocolVersion20250326))
+	assert.Equal(t, ProtocolFeatures{Completions: true, AudioContent: true, StructuredOutput: true, Elicitation: true}, FeaturesOf(ProtocolVersion20250618))
+}
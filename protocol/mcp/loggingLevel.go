@@ -0,0 +1,39 @@
+package mcp
+
+import "slices"
+
+// LoggingLevel is the severity of a log message, as defined in RFC 5424
+type LoggingLevel string
+
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// from the least to the most severe
+var loggingLevels = []LoggingLevel{
+	LoggingLevelDebug,
+	LoggingLevelInfo,
+	LoggingLevelNotice,
+	LoggingLevelWarning,
+	LoggingLevelError,
+	LoggingLevelCritical,
+	LoggingLevelAlert,
+	LoggingLevelEmergency,
+}
+
+func (l LoggingLevel) IsValid() bool {
+	return slices.Contains(loggingLevels, l)
+}
+
+// Severity returns the rank of the level, higher is more severe
+// it returns -1 for an unknown level
+func (l LoggingLevel) Severity() int {
+	return slices.Index(loggingLevels, l)
+}
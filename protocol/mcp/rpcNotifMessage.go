@@ -0,0 +1,11 @@
+package mcp
+
+const (
+	RpcNotificationMethodMessage = "notifications/message"
+)
+
+type JsonRpcNotificationMessageParams struct {
+	Level  LoggingLevel `json:"level"`
+	Logger string       `json:"logger,omitempty"`
+	Data   interface{}  `json:"data"`
+}
@@ -4,4 +4,6 @@ const (
 	RpcNotificationMethodInitialized          = "notifications/initialized"
 	RpcNotificationMethodToolsListChanged     = "notifications/tools/list_changed"
 	RpcNotificationMethodResourcesListChanged = "notifications/resources/list_changed"
+	RpcNotificationMethodPromptsListChanged   = "notifications/prompts/list_changed"
+	RpcNotificationMethodRootsListChanged     = "notifications/roots/list_changed"
 )
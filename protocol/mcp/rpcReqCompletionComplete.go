@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+// specification
+// https://spec.modelcontextprotocol.io/specification/server/utilities/completion/
+
+const (
+	RpcRequestMethodCompletionComplete = "completion/complete"
+)
+
+// the types of CompletionReference
+const (
+	CompletionRefPrompt   = "ref/prompt"
+	CompletionRefResource = "ref/resource"
+)
+
+type JsonRpcRequestCompletionCompleteParams struct {
+	Ref      CompletionReference `json:"ref"`
+	Argument CompletionArgument  `json:"argument"`
+}
+
+// CompletionReference is a prompt, with Name, or a resource template, with Uri
+type CompletionReference struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	Uri  string `json:"uri,omitempty"`
+}
+
+type CompletionArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func ParseJsonRpcRequestCompletionComplete(params *jsonrpc.JsonRpcParams) (*JsonRpcRequestCompletionCompleteParams, error) {
+	resp := &JsonRpcRequestCompletionCompleteParams{}
+
+	// check if we have params
+	if params == nil {
+		return nil, fmt.Errorf("invalid call parameters, no parameters provided")
+	}
+
+	if !params.IsNamed() {
+		return nil, fmt.Errorf("invalid call parameters, not an object")
+	}
+
+	ref, err := protocol.GetObjectField(params.NamedParams, "ref")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, ref is not an object")
+	}
+	refType, err := protocol.GetStringField(ref, "type")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, ref.type is not a string")
+	}
+	resp.Ref.Type = refType
+	switch refType {
+	case CompletionRefPrompt:
+		name, err := protocol.GetStringField(ref, "name")
+		if err != nil {
+			return nil, fmt.Errorf("invalid call parameters, ref.name is not a string")
+		}
+		resp.Ref.Name = name
+	case CompletionRefResource:
+		uri, err := protocol.GetStringField(ref, "uri")
+		if err != nil {
+			return nil, fmt.Errorf("invalid call parameters, ref.uri is not a string")
+		}
+		resp.Ref.Uri = uri
+	default:
+		return nil, fmt.Errorf("invalid call parameters, unknown ref.type: %s", refType)
+	}
+
+	argument, err := protocol.GetObjectField(params.NamedParams, "argument")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, argument is not an object")
+	}
+	name, err := protocol.GetStringField(argument, "name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, argument.name is not a string")
+	}
+	resp.Argument.Name = name
+	value, err := protocol.GetStringField(argument, "value")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, argument.value is not a string")
+	}
+	resp.Argument.Value = value
+
+	return resp, nil
+}
@@ -0,0 +1,16 @@
+package mcp
+
+// specification
+// https://modelcontextprotocol.io/specification/2025-06-18/client/elicitation
+
+// elicitation/create is sent by the server to the client
+const (
+	RpcRequestMethodElicitationCreate = "elicitation/create"
+)
+
+type JsonRpcRequestElicitationCreateParams struct {
+	Message string `json:"message"`
+	// a flat object schema, the properties can only be strings,
+	// numbers, integers, booleans or enums of strings
+	RequestedSchema interface{} `json:"requestedSchema"`
+}
@@ -20,9 +20,14 @@ type JsonRpcRequestInitializeParams struct {
 	ClientInfo      ClientInfo         `json:"clientInfo"`
 }
 
+// ClientCapabilities lists the features supported by the client,
+// a nil field means that the client does not support the feature
 type ClientCapabilities struct {
-	Roots    ClientCapabilitiesRoots    `json:"roots"`
-	Sampling ClientCapabilitiesSampling `json:"sampling"`
+	Roots       *ClientCapabilitiesRoots       `json:"roots,omitempty"`
+	Sampling    *ClientCapabilitiesSampling    `json:"sampling,omitempty"`
+	Elicitation *ClientCapabilitiesElicitation `json:"elicitation,omitempty"`
+	// non-standard capabilities, kept as sent by the client
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
 }
 
 type ClientCapabilitiesRoots struct {
@@ -32,6 +37,9 @@ type ClientCapabilitiesRoots struct {
 type ClientCapabilitiesSampling struct {
 }
 
+type ClientCapabilitiesElicitation struct {
+}
+
 type ClientInfo struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
@@ -72,7 +80,58 @@ func ParseJsonRpcRequestInitialize(request *jsonrpc.JsonRpcRequest) (*JsonRpcReq
 	}
 	req.ClientInfo.Version = version
 
-	// TODO: read capabilities and roots
+	// read capabilities, some clients don't send them when they have none
+	capabilities := protocol.GetOptionalObjectField(namedParams, "capabilities")
+	if capabilities == nil {
+		if _, found := namedParams["capabilities"]; found {
+			return nil, fmt.Errorf("capabilities must be an object")
+		}
+	} else {
+		parsed, err := parseClientCapabilities(capabilities)
+		if err != nil {
+			return nil, fmt.Errorf("capabilities.%v", err)
+		}
+		req.Capabilities = *parsed
+	}
 
 	return &req, nil
 }
+
+func parseClientCapabilities(capabilities map[string]interface{}) (*ClientCapabilities, error) {
+	parsed := ClientCapabilities{}
+
+	if _, found := capabilities["roots"]; found {
+		roots, err := protocol.GetObjectField(capabilities, "roots")
+		if err != nil {
+			return nil, fmt.Errorf("roots must be an object")
+		}
+		parsed.Roots = &ClientCapabilitiesRoots{}
+		if listChanged := protocol.GetOptionalBoolField(roots, "listChanged"); listChanged != nil {
+			parsed.Roots.ListChanged = *listChanged
+		}
+	}
+
+	if _, found := capabilities["sampling"]; found {
+		if _, err := protocol.GetObjectField(capabilities, "sampling"); err != nil {
+			return nil, fmt.Errorf("sampling must be an object")
+		}
+		parsed.Sampling = &ClientCapabilitiesSampling{}
+	}
+
+	if _, found := capabilities["elicitation"]; found {
+		if _, err := protocol.GetObjectField(capabilities, "elicitation"); err != nil {
+			return nil, fmt.Errorf("elicitation must be an object")
+		}
+		parsed.Elicitation = &ClientCapabilitiesElicitation{}
+	}
+
+	if _, found := capabilities["experimental"]; found {
+		experimental, err := protocol.GetObjectField(capabilities, "experimental")
+		if err != nil {
+			return nil, fmt.Errorf("experimental must be an object")
+		}
+		parsed.Experimental = experimental
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+// specification
+// https://spec.modelcontextprotocol.io/specification/server/utilities/logging/
+
+const (
+	RpcRequestMethodLoggingSetLevel = "logging/setLevel"
+)
+
+type JsonRpcRequestLoggingSetLevelParams struct {
+	Level LoggingLevel `json:"level"`
+}
+
+func ParseJsonRpcRequestLoggingSetLevel(params *jsonrpc.JsonRpcParams) (*JsonRpcRequestLoggingSetLevelParams, error) {
+	if params == nil {
+		return nil, fmt.Errorf("invalid call parameters, no parameters provided")
+	}
+	if !params.IsNamed() {
+		return nil, fmt.Errorf("invalid call parameters, not an object")
+	}
+
+	level, err := protocol.GetStringField(params.NamedParams, "level")
+	if err != nil {
+		return nil, fmt.Errorf("missing level")
+	}
+	if !LoggingLevel(level).IsValid() {
+		return nil, fmt.Errorf("invalid level: %s", level)
+	}
+
+	return &JsonRpcRequestLoggingSetLevelParams{
+		Level: LoggingLevel(level),
+	}, nil
+}
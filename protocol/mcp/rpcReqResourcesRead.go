@@ -0,0 +1,34 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+const (
+	RpcRequestMethodResourcesRead = "resources/read"
+)
+
+type JsonRpcRequestResourcesReadParams struct {
+	Uri string `json:"uri"`
+}
+
+func ParseJsonRpcRequestResourcesRead(params *jsonrpc.JsonRpcParams) (*JsonRpcRequestResourcesReadParams, error) {
+	if params == nil {
+		return nil, fmt.Errorf("invalid call parameters, no parameters provided")
+	}
+	if !params.IsNamed() {
+		return nil, fmt.Errorf("invalid call parameters, not an object")
+	}
+
+	uri, err := protocol.GetStringField(params.NamedParams, "uri")
+	if err != nil {
+		return nil, fmt.Errorf("invalid call parameters, uri is not a string")
+	}
+
+	return &JsonRpcRequestResourcesReadParams{
+		Uri: uri,
+	}, nil
+}
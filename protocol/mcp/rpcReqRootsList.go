@@ -0,0 +1,9 @@
+package mcp
+
+// specification
+// https://spec.modelcontextprotocol.io/specification/client/roots/
+
+// roots/list is sent by the server to the client, it has no params
+const (
+	RpcRequestMethodRootsList = "roots/list"
+)
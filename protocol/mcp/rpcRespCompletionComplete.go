@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+// CompletionMaxValues is the maximum number of values of a completion
+const CompletionMaxValues = 100
+
+type JsonRpcResponseCompletionCompleteResult struct {
+	Completion CompletionValues `json:"completion"`
+}
+
+type CompletionValues struct {
+	Values []string `json:"values"`
+	// the total number of values, which can exceed the number of values sent
+	Total   *int  `json:"total,omitempty"`
+	HasMore *bool `json:"hasMore,omitempty"`
+}
+
+func ParseJsonRpcResponseCompletionComplete(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseCompletionCompleteResult, error) {
+	resp := JsonRpcResponseCompletionCompleteResult{}
+
+	// parse params
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := protocol.GetObjectField(result, "completion")
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := protocol.GetArrayField(completion, "values")
+	if err != nil {
+		return nil, err
+	}
+	resp.Completion.Values = make([]string, 0, len(values))
+	for _, value := range values {
+		value, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("values must be an array of strings")
+		}
+		resp.Completion.Values = append(resp.Completion.Values, value)
+	}
+
+	if total, ok := completion["total"].(float64); ok {
+		totalInt := int(total)
+		resp.Completion.Total = &totalInt
+	}
+	resp.Completion.HasMore = protocol.GetOptionalBoolField(completion, "hasMore")
+
+	return &resp, nil
+}
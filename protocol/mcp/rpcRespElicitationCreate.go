@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+// the actions of the user
+const (
+	ElicitationActionAccept  = "accept"
+	ElicitationActionDecline = "decline"
+	ElicitationActionCancel  = "cancel"
+)
+
+type JsonRpcResponseElicitationCreateResult struct {
+	Action string `json:"action"`
+	// only set when the user accepts
+	Content map[string]interface{} `json:"content,omitempty"`
+}
+
+func ParseJsonRpcResponseElicitationCreate(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseElicitationCreateResult, error) {
+	resp := JsonRpcResponseElicitationCreateResult{}
+
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := protocol.GetStringField(result, "action")
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case ElicitationActionAccept:
+		content, err := protocol.GetObjectField(result, "content")
+		if err != nil {
+			return nil, err
+		}
+		resp.Content = content
+	case ElicitationActionDecline, ElicitationActionCancel:
+	default:
+		return nil, fmt.Errorf("invalid action %s", action)
+	}
+	resp.Action = action
+
+	return &resp, nil
+}
@@ -21,6 +21,8 @@ type ServerCapabilities struct {
 	Prompts   *ServerCapabilitiesPrompts   `json:"prompts,omitempty"`
 	Logging   *ServerCapabilitiesLogging   `json:"logging,omitempty"`
 	Resources *ServerCapabilitiesResources `json:"resources,omitempty"`
+	// since 2025-03-26
+	Completions *ServerCapabilitiesCompletions `json:"completions,omitempty"`
 }
 
 type ServerCapabilitiesTools struct {
@@ -34,6 +36,9 @@ type ServerCapabilitiesPrompts struct {
 type ServerCapabilitiesLogging struct {
 }
 
+type ServerCapabilitiesCompletions struct {
+}
+
 type ServerCapabilitiesResources struct {
 	ListChanged *bool `json:"listChanged,omitempty"`
 	Subscribe   *bool `json:"subscribe,omitempty"`
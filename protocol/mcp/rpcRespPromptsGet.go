@@ -1,11 +1,61 @@
 package mcp
 
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
 type JsonRpcResponsePromptsGetResult struct {
 	Description string          `json:"description"`
 	Messages    []PromptMessage `json:"messages"`
 }
 
 type PromptMessage struct {
-	Role    string `json:"role"` // "user" or "assistant"
-	Content string `json:"content"`
+	Role string `json:"role"` // "user" or "assistant"
+	// text, image or embedded resource
+	Content map[string]interface{} `json:"content"`
+}
+
+func ParseJsonRpcResponsePromptsGet(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponsePromptsGetResult, error) {
+	resp := JsonRpcResponsePromptsGetResult{
+		Messages: []PromptMessage{},
+	}
+
+	// parse params
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	// the description is optional
+	description := protocol.GetOptionalStringField(result, "description")
+	if description != nil {
+		resp.Description = *description
+	}
+
+	// read messages
+	messages, err := protocol.GetArrayField(result, "messages")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range messages {
+		message, err := protocol.CheckIsObject(item, "message")
+		if err != nil {
+			return nil, err
+		}
+		role, err := protocol.GetStringField(message, "role")
+		if err != nil {
+			return nil, err
+		}
+		content, err := protocol.GetObjectField(message, "content")
+		if err != nil {
+			return nil, err
+		}
+		resp.Messages = append(resp.Messages, PromptMessage{
+			Role:    role,
+			Content: content,
+		})
+	}
+
+	return &resp, nil
 }
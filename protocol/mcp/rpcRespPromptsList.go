@@ -47,15 +47,13 @@ func ParseJsonRpcResponsePromptsList(response *jsonrpc.JsonRpcResponse) (*JsonRp
 			return nil, err
 		}
 
-		description, err := protocol.GetStringField(prompt, "description")
-		if err != nil {
-			return nil, err
+		// the description and the arguments are optional in the specification
+		description := ""
+		if value := protocol.GetOptionalStringField(prompt, "description"); value != nil {
+			description = *value
 		}
 
-		arguments, err := protocol.GetArrayField(prompt, "arguments")
-		if err != nil {
-			return nil, err
-		}
+		arguments := protocol.GetOptionalArrayField(prompt, "arguments")
 
 		promptArguments := make([]PromptArgumentDescription, 0)
 		for _, argument := range arguments {
@@ -67,13 +65,13 @@ func ParseJsonRpcResponsePromptsList(response *jsonrpc.JsonRpcResponse) (*JsonRp
 			if err != nil {
 				return nil, err
 			}
-			description, err := protocol.GetStringField(argument, "description")
-			if err != nil {
-				return nil, err
+			description := ""
+			if value := protocol.GetOptionalStringField(argument, "description"); value != nil {
+				description = *value
 			}
-			required, err := protocol.GetBoolField(argument, "required")
-			if err != nil {
-				return nil, err
+			required := false
+			if value := protocol.GetOptionalBoolField(argument, "required"); value != nil {
+				required = *value
 			}
 			promptArguments = append(promptArguments, PromptArgumentDescription{
 				Name:        name,
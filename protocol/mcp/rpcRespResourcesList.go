@@ -1,7 +1,13 @@
 package mcp
 
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
 type JsonRpcResponseResourcesListResult struct {
-	Resources []ResourceDescription `json:"resources"`
+	Resources  []ResourceDescription `json:"resources"`
+	NextCursor *string               `json:"nextCursor,omitempty"`
 }
 type ResourceDescription struct {
 	Uri         string `json:"uri"`
@@ -9,3 +15,54 @@ type ResourceDescription struct {
 	Description string `json:"description"`
 	MimeType    string `json:"mimeType"`
 }
+
+func ParseJsonRpcResponseResourcesList(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseResourcesListResult, error) {
+	resp := JsonRpcResponseResourcesListResult{
+		Resources: []ResourceDescription{},
+	}
+
+	// parse params
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	// read resources
+	resources, err := protocol.GetArrayField(result, "resources")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range resources {
+		resource, err := protocol.CheckIsObject(item, "resource")
+		if err != nil {
+			return nil, err
+		}
+		uri, err := protocol.GetStringField(resource, "uri")
+		if err != nil {
+			return nil, err
+		}
+		name, err := protocol.GetStringField(resource, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		description := ResourceDescription{
+			Uri:  uri,
+			Name: name,
+		}
+		if value := protocol.GetOptionalStringField(resource, "description"); value != nil {
+			description.Description = *value
+		}
+		if value := protocol.GetOptionalStringField(resource, "mimeType"); value != nil {
+			description.MimeType = *value
+		}
+		resp.Resources = append(resp.Resources, description)
+	}
+
+	// read next cursor
+	nextCursor := protocol.GetOptionalStringField(result, "nextCursor")
+	resp.NextCursor = nextCursor
+
+	return &resp, nil
+}
@@ -0,0 +1,28 @@
+package mcp
+
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+type JsonRpcResponseResourcesReadResult struct {
+	// text or blob resource contents
+	Contents []interface{} `json:"contents"`
+}
+
+func ParseJsonRpcResponseResourcesRead(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseResourcesReadResult, error) {
+	// parse params
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := protocol.GetArrayField(result, "contents")
+	if err != nil {
+		return nil, err
+	}
+
+	return &JsonRpcResponseResourcesReadResult{
+		Contents: contents,
+	}, nil
+}
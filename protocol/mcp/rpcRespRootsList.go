@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+type JsonRpcResponseRootsListResult struct {
+	Roots []Root `json:"roots"`
+}
+
+type Root struct {
+	Uri  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+func ParseJsonRpcResponseRootsList(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseRootsListResult, error) {
+	resp := JsonRpcResponseRootsListResult{
+		Roots: []Root{},
+	}
+
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := protocol.GetArrayField(result, "roots")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range roots {
+		root, err := protocol.CheckIsObject(item, "root")
+		if err != nil {
+			return nil, err
+		}
+		uri, err := protocol.GetStringField(root, "uri")
+		if err != nil {
+			return nil, err
+		}
+		// the name is optional in the specification
+		name := ""
+		if value := protocol.GetOptionalStringField(root, "name"); value != nil {
+			name = *value
+		}
+		resp.Roots = append(resp.Roots, Root{Uri: uri, Name: name})
+	}
+
+	return &resp, nil
+}
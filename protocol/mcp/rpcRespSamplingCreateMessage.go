@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/protocol"
+)
+
+type JsonRpcResponseSamplingCreateMessageResult struct {
+	Role       string          `json:"role"`
+	Content    SamplingContent `json:"content"`
+	Model      string          `json:"model"`
+	StopReason string          `json:"stopReason,omitempty"`
+}
+
+func ParseJsonRpcResponseSamplingCreateMessage(response *jsonrpc.JsonRpcResponse) (*JsonRpcResponseSamplingCreateMessageResult, error) {
+	resp := JsonRpcResponseSamplingCreateMessageResult{}
+
+	result, err := protocol.CheckIsObject(response.Result, "result")
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := protocol.GetStringField(result, "role")
+	if err != nil {
+		return nil, err
+	}
+	resp.Role = role
+
+	model, err := protocol.GetStringField(result, "model")
+	if err != nil {
+		return nil, err
+	}
+	resp.Model = model
+
+	// the stop reason is optional in the specification
+	if stopReason := protocol.GetOptionalStringField(result, "stopReason"); stopReason != nil {
+		resp.StopReason = *stopReason
+	}
+
+	content, err := protocol.GetObjectField(result, "content")
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := protocol.GetStringField(content, "type")
+	if err != nil {
+		return nil, err
+	}
+	resp.Content.Type = contentType
+	switch contentType {
+	case "text":
+		text, err := protocol.GetStringField(content, "text")
+		if err != nil {
+			return nil, err
+		}
+		resp.Content.Text = text
+	default:
+		// images and audio
+		data, err := protocol.GetStringField(content, "data")
+		if err != nil {
+			return nil, err
+		}
+		resp.Content.Data = data
+		mimeType, err := protocol.GetStringField(content, "mimeType")
+		if err != nil {
+			return nil, err
+		}
+		resp.Content.MimeType = mimeType
+	}
+
+	return &resp, nil
+}
@@ -41,9 +41,10 @@ func ParseJsonRpcResponseToolsList(response *jsonrpc.JsonRpcResponse) (*JsonRpcR
 			return nil, err
 		}
 
-		description, err := protocol.GetStringField(tool, "description")
-		if err != nil {
-			return nil, err
+		// the description is optional in the specification
+		description := ""
+		if value := protocol.GetOptionalStringField(tool, "description"); value != nil {
+			description = *value
 		}
 
 		inputSchema, err := protocol.GetObjectField(tool, "inputSchema")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/llmcontext/gomcp/jsonrpc"
 	"github.com/llmcontext/gomcp/modelcontextprotocol"
+	"github.com/llmcontext/gomcp/pkg/pagination"
 	"github.com/llmcontext/gomcp/protocol/mcp"
 	"github.com/llmcontext/gomcp/providers/sdk"
 	"github.com/llmcontext/gomcp/types"
@@ -31,14 +32,27 @@ func NewProviderMcpServerHandler(
 	}, nil
 }
 
-func (n *ProviderMcpServerHandler) ExecuteToolsList(ctx context.Context, logger types.Logger) (*mcp.JsonRpcResponseToolsListResult, *jsonrpc.JsonRpcError) {
+func (n *ProviderMcpServerHandler) ExecuteToolsList(ctx context.Context, params *mcp.JsonRpcRequestToolsListParams, logger types.Logger) (*mcp.JsonRpcResponseToolsListResult, *jsonrpc.JsonRpcError) {
 	result := &mcp.JsonRpcResponseToolsListResult{
 		Tools: make([]mcp.ToolDescription, 0, 10),
 	}
 
 	// get the tools from the sdk
 	tools := n.sdkServerDefinition.GetListOfTools()
+	names := make([]string, 0, len(tools))
 	for _, tool := range tools {
+		names = append(names, tool.ToolName)
+	}
+	start, end, nextCursor, err := pagination.Page(names, params.Cursor, n.sdkServerDefinition.ListPageSize())
+	if err != nil {
+		return nil, &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInvalidParams,
+			Message: err.Error(),
+		}
+	}
+	result.NextCursor = nextCursor
+
+	for _, tool := range tools[start:end] {
 		result.Tools = append(result.Tools, mcp.ToolDescription{
 			Name:        tool.ToolName,
 			Description: tool.ToolDescription,
@@ -63,7 +77,7 @@ func (n *ProviderMcpServerHandler) ExecuteToolCall(
 	// check if the tool is available in the sdk
 	tool := n.sdkServerDefinition.GetTool(toolName)
 	if tool != nil {
-		return n.sdkServerDefinition.ExecuteToolCall(ctx, params, logger)
+		return n.sdkServerDefinition.ExecuteToolCall(ctx, params, n.logger, logger)
 	}
 
 	// if the tool is not found in the sdk, return an error
@@ -73,13 +87,26 @@ func (n *ProviderMcpServerHandler) ExecuteToolCall(
 	}
 }
 
-func (n *ProviderMcpServerHandler) ExecutePromptsList(ctx context.Context, logger types.Logger) (*mcp.JsonRpcResponsePromptsListResult, *jsonrpc.JsonRpcError) {
+func (n *ProviderMcpServerHandler) ExecutePromptsList(ctx context.Context, params *mcp.JsonRpcRequestPromptsListParams, logger types.Logger) (*mcp.JsonRpcResponsePromptsListResult, *jsonrpc.JsonRpcError) {
 	var response = mcp.JsonRpcResponsePromptsListResult{
 		Prompts: make([]mcp.PromptDescription, 0),
 	}
 
 	prompts := n.sdkServerDefinition.GetListOfPrompts()
+	names := make([]string, 0, len(prompts))
 	for _, prompt := range prompts {
+		names = append(names, prompt.Name)
+	}
+	start, end, nextCursor, err := pagination.Page(names, params.Cursor, n.sdkServerDefinition.ListPageSize())
+	if err != nil {
+		return nil, &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInvalidParams,
+			Message: err.Error(),
+		}
+	}
+	response.NextCursor = nextCursor
+
+	for _, prompt := range prompts[start:end] {
 		arguments := make([]mcp.PromptArgumentDescription, 0, len(prompt.Arguments))
 		for _, argument := range prompt.Arguments {
 			arguments = append(arguments, mcp.PromptArgumentDescription{
@@ -102,6 +129,11 @@ func (n *ProviderMcpServerHandler) ExecutePromptGet(ctx context.Context, params
 	promptName := params.Name
 	templateArgs := params.Arguments
 
+	// the prompts declared with AddPrompt are built by a Go function
+	if n.sdkServerDefinition.GetPromptDefinition(promptName) != nil {
+		return n.sdkServerDefinition.ExecutePromptGet(ctx, params, n.logger, logger)
+	}
+
 	response, err := n.sdkServerDefinition.GetPrompt(promptName, templateArgs)
 	if err != nil {
 		return nil, &jsonrpc.JsonRpcError{
@@ -112,3 +144,7 @@ func (n *ProviderMcpServerHandler) ExecutePromptGet(ctx context.Context, params
 
 	return response, nil
 }
+
+func (n *ProviderMcpServerHandler) ExecuteCompletion(ctx context.Context, params *mcp.JsonRpcRequestCompletionCompleteParams, logger types.Logger) (*mcp.JsonRpcResponseCompletionCompleteResult, *jsonrpc.JsonRpcError) {
+	return n.sdkServerDefinition.ExecuteCompletion(ctx, params, logger)
+}
@@ -1,9 +1,10 @@
 package registry
 
 import (
-	"bytes"
 	"fmt"
-	"html/template"
+	"io/fs"
+	"sync"
+	"text/template"
 
 	"github.com/llmcontext/gomcp/pkg/prompts"
 	"github.com/llmcontext/gomcp/providers/results"
@@ -11,37 +12,161 @@ import (
 )
 
 type PromptsRegistry struct {
+	// protects prompts, replaced when the files are reloaded
+	mu      sync.RWMutex
 	prompts []*prompts.PromptDefinition
+	// the files the prompts were loaded from, in order
+	sources []*promptSource
+	// functions available to the text and sprig templates
+	funcs template.FuncMap
+	// the prompts declared outside of the files, nil if there are none
+	isReserved func(name string) bool
+}
+
+type promptSource struct {
+	// the prompts file, empty for a file system
+	filePath string
+	load     func() (*prompts.PromptList, error)
 }
 
 func NewPromptsRegistry() *PromptsRegistry {
-	return &PromptsRegistry{prompts: []*prompts.PromptDefinition{}}
+	return &PromptsRegistry{
+		prompts: []*prompts.PromptDefinition{},
+		sources: []*promptSource{},
+		funcs:   template.FuncMap{},
+	}
+}
+
+// AddFuncs makes the functions available to all the templates,
+// they override the helpers of the sprig engine with the same name
+func (r *PromptsRegistry) AddFuncs(funcs template.FuncMap) {
+	for name, function := range funcs {
+		r.funcs[name] = function
+	}
+}
+
+// ReserveNames declares the prompts handled outside of the files, eg. with
+// AddPrompt: loading or reloading a file declaring one of them fails
+func (r *PromptsRegistry) ReserveNames(isReserved func(name string) bool) {
+	r.isReserved = isReserved
 }
 
 func (r *PromptsRegistry) LoadPromptYamlFile(promptYamlFilePath string) ([]*prompts.DuplicatedPrompt, error) {
-	duplicatedPrompts := make([]*prompts.DuplicatedPrompt, 0)
-	loadedPrompts, err := prompts.LoadPromptYamlFile(promptYamlFilePath)
+	return r.addSource(&promptSource{
+		filePath: promptYamlFilePath,
+		load: func() (*prompts.PromptList, error) {
+			return prompts.LoadPromptYamlFile(promptYamlFilePath)
+		},
+	})
+}
+
+// LoadPromptFS loads the prompts files of fsys matching pattern, see prompts.LoadPromptFS
+func (r *PromptsRegistry) LoadPromptFS(fsys fs.FS, pattern string) ([]*prompts.DuplicatedPrompt, error) {
+	return r.addSource(&promptSource{
+		load: func() (*prompts.PromptList, error) {
+			return prompts.LoadPromptFS(fsys, pattern)
+		},
+	})
+}
+
+func (r *PromptsRegistry) addSource(source *promptSource) ([]*prompts.DuplicatedPrompt, error) {
+	loadedPrompts, err := source.load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.checkReservedNames(loadedPrompts.Prompts)
 	if err != nil {
 		return nil, err
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, source)
+	var duplicatedPrompts []*prompts.DuplicatedPrompt
+	r.prompts, duplicatedPrompts = appendPrompts(r.prompts, loadedPrompts)
+	return duplicatedPrompts, nil
+}
+
+// appendPrompts adds the loaded prompts to the list, the
+// prompts already in the list are returned as duplicated
+func appendPrompts(list []*prompts.PromptDefinition, loadedPrompts *prompts.PromptList) ([]*prompts.PromptDefinition, []*prompts.DuplicatedPrompt) {
+	duplicatedPrompts := make([]*prompts.DuplicatedPrompt, 0)
 	// make sure we don't have duplicated prompts
 	for _, prompt := range loadedPrompts.Prompts {
-		if r.findPrompt(prompt.Name) != nil {
-			duplicatedPrompts = append(duplicatedPrompts, &prompts.DuplicatedPrompt{PromptName: prompt.Name, FilePath: promptYamlFilePath})
+		if findPrompt(list, prompt.Name) != nil {
+			duplicatedPrompts = append(duplicatedPrompts, &prompts.DuplicatedPrompt{PromptName: prompt.Name, FilePath: prompt.SourceFile})
 		} else {
-			r.prompts = append(r.prompts, prompt)
+			list = append(list, prompt)
 		}
 	}
+	return list, duplicatedPrompts
+}
 
+// Reload loads all the prompts files again. The prompts are replaced at once,
+// and only if all the files are valid: the previous ones are kept otherwise.
+// Like when the files are added, the first definition of a prompt declared
+// twice is kept and the other ones are returned as duplicated
+func (r *PromptsRegistry) Reload() ([]*prompts.DuplicatedPrompt, error) {
+	r.mu.RLock()
+	sources := r.sources
+	r.mu.RUnlock()
+
+	reloaded := []*prompts.PromptDefinition{}
+	duplicatedPrompts := []*prompts.DuplicatedPrompt{}
+	for _, source := range sources {
+		loadedPrompts, err := source.load()
+		if err != nil {
+			if source.filePath != "" {
+				return nil, fmt.Errorf("%s: %v", source.filePath, err)
+			}
+			return nil, err
+		}
+		var duplicated []*prompts.DuplicatedPrompt
+		reloaded, duplicated = appendPrompts(reloaded, loadedPrompts)
+		duplicatedPrompts = append(duplicatedPrompts, duplicated...)
+	}
+	err := r.checkReservedNames(reloaded)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prompts = reloaded
 	return duplicatedPrompts, nil
 }
 
+func (r *PromptsRegistry) checkReservedNames(list []*prompts.PromptDefinition) error {
+	if r.isReserved == nil {
+		return nil
+	}
+	for _, prompt := range list {
+		if r.isReserved(prompt.Name) {
+			return fmt.Errorf("prompt %s is declared both in a prompts file and with AddPrompt", prompt.Name)
+		}
+	}
+	return nil
+}
+
 func (r *PromptsRegistry) GetListOfPrompts() []*prompts.PromptDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return r.prompts
 }
 
+func (r *PromptsRegistry) HasPrompt(name string) bool {
+	return r.findPrompt(name) != nil
+}
+
 func (r *PromptsRegistry) findPrompt(name string) *prompts.PromptDefinition {
-	for _, prompt := range r.prompts {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return findPrompt(r.prompts, name)
+}
+
+func findPrompt(list []*prompts.PromptDefinition, name string) *prompts.PromptDefinition {
+	for _, prompt := range list {
 		if prompt.Name == name {
 			return prompt
 		}
@@ -67,22 +192,29 @@ func (r *PromptsRegistry) GetPrompt(promptName string, arguments map[string]stri
 		templateArgs[argument.Name] = argumentValue
 	}
 
-	tmpl, err := template.New(promptName).Parse(prompt.Prompt)
+	messages, err := prompts.RenderMessages(prompt, templateArgs, r.funcs)
 	if err != nil {
-		return nil, fmt.Errorf("invalid prompt template: %s", err)
-	}
-
-	var renderedPrompt bytes.Buffer
-	err = tmpl.Execute(&renderedPrompt, templateArgs)
-	if err != nil {
-		return nil, fmt.Errorf("invalid prompt rendering: %s", err)
+		return nil, err
 	}
-	promptResult := renderedPrompt.String()
 
 	// let's create the output
 	output := results.NewPromptGetResult(prompt.Description)
 
-	output.AddTextContent(types.RoleUser, promptResult)
+	for _, message := range messages {
+		role := types.Role(message.Role)
+		switch message.Type {
+		case prompts.MessageTypeImage:
+			output.AddImageContent(role, message.MimeType, message.Data)
+		case prompts.MessageTypeResource:
+			if message.Data != "" {
+				output.AddEmbeddedResourceBlobContent(role, message.Uri, message.MimeType, message.Data)
+			} else {
+				output.AddEmbeddedResourceTextContent(role, message.Uri, message.MimeType, message.Text)
+			}
+		default:
+			output.AddTextContent(role, message.Text)
+		}
+	}
 
 	return output, nil
 }
@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/prompts"
+)
+
+// the state of a prompts file, a change triggers a reload
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// Watch polls the files added with LoadPromptYamlFile every interval and
+// reloads the prompts when one of them changes. onReload is called after
+// each reload with the result of Reload, err is nil if the prompts were replaced.
+// Watch returns when ctx is done.
+func (r *PromptsRegistry) Watch(ctx context.Context, interval time.Duration, onReload func(duplicatedPrompts []*prompts.DuplicatedPrompt, err error)) {
+	states := r.fileStates()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := r.fileStates()
+		if sameFileStates(states, current) {
+			continue
+		}
+		// a file being written may be invalid: the next
+		// change of the file triggers a new reload
+		states = current
+		onReload(r.Reload())
+	}
+}
+
+func (r *PromptsRegistry) fileStates() map[string]fileState {
+	r.mu.RLock()
+	sources := r.sources
+	r.mu.RUnlock()
+
+	states := make(map[string]fileState)
+	for _, source := range sources {
+		if source.filePath == "" {
+			continue
+		}
+		info, err := os.Stat(source.filePath)
+		if err != nil {
+			states[source.filePath] = fileState{}
+			continue
+		}
+		states[source.filePath] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+	}
+	return states
+}
+
+func sameFileStates(previous map[string]fileState, current map[string]fileState) bool {
+	if len(previous) != len(current) {
+		return false
+	}
+	for filePath, state := range current {
+		before, found := previous[filePath]
+		if !found || before.exists != state.exists || before.size != state.size || !before.modTime.Equal(state.modTime) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/prompts"
+	"github.com/stretchr/testify/assert"
+)
+
+const helloPrompts = `prompts:
+  - name: hello
+    description: Says hello
+    prompt: Hello!
+`
+
+func TestWatchReloadsPrompts(t *testing.T) {
+	promptsFile := filepath.Join(t.TempDir(), "prompts.yaml")
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(helloPrompts), 0644))
+
+	registry := NewPromptsRegistry()
+	_, err := registry.LoadPromptYamlFile(promptsFile)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan error)
+	go registry.Watch(ctx, 10*time.Millisecond, func(duplicatedPrompts []*prompts.DuplicatedPrompt, err error) {
+		reloads <- err
+	})
+	// let the watcher record the initial state of the file
+	time.Sleep(50 * time.Millisecond)
+
+	// an invalid file keeps the previous prompts
+	assert.NoError(t, os.WriteFile(promptsFile, []byte("prompts:\n  - name: [\n"), 0644))
+	assert.Error(t, <-reloads)
+	assert.True(t, registry.HasPrompt("hello"))
+
+	updated := helloPrompts + `  - name: goodbye
+    description: Says goodbye
+    prompt: Goodbye!
+`
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(updated), 0644))
+	assert.NoError(t, <-reloads)
+	assert.Len(t, registry.GetListOfPrompts(), 2)
+	assert.True(t, registry.HasPrompt("goodbye"))
+}
+
+func TestReloadConflicts(t *testing.T) {
+	promptsFile := filepath.Join(t.TempDir(), "prompts.yaml")
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(helloPrompts), 0644))
+
+	registry := NewPromptsRegistry()
+	registry.ReserveNames(func(name string) bool { return name == "goodbye" })
+	_, err := registry.LoadPromptYamlFile(promptsFile)
+	assert.NoError(t, err)
+
+	// a prompt declared twice, the first definition is kept
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(helloPrompts+`  - name: hello
+    description: Says hello again
+    prompt: Hello again!
+`), 0644))
+	duplicatedPrompts, err := registry.Reload()
+	assert.NoError(t, err)
+	if assert.Len(t, duplicatedPrompts, 1) {
+		assert.Equal(t, "hello", duplicatedPrompts[0].PromptName)
+	}
+
+	// a prompt declared outside of the files keeps the previous prompts
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(helloPrompts+`  - name: goodbye
+    description: Says goodbye
+    prompt: Goodbye!
+`), 0644))
+	_, err = registry.Reload()
+	assert.ErrorContains(t, err, "prompt goodbye")
+
+	list := registry.GetListOfPrompts()
+	assert.Len(t, list, 1)
+	assert.Equal(t, "Says hello", list[0].Description)
+}
+
+func TestWatchWithDuplicateAtStartup(t *testing.T) {
+	promptsFile := filepath.Join(t.TempDir(), "prompts.yaml")
+	duplicated := helloPrompts + `  - name: hello
+    description: Says hello again
+    prompt: Hello again!
+`
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(duplicated), 0644))
+
+	registry := NewPromptsRegistry()
+	duplicatedPrompts, err := registry.LoadPromptYamlFile(promptsFile)
+	assert.NoError(t, err)
+	assert.Len(t, duplicatedPrompts, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan error)
+	go registry.Watch(ctx, 10*time.Millisecond, func(duplicatedPrompts []*prompts.DuplicatedPrompt, err error) {
+		reloads <- err
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	// the duplicate is still there, the new prompt is picked up anyway
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(duplicated+`  - name: goodbye
+    description: Says goodbye
+    prompt: Goodbye!
+`), 0644))
+	assert.NoError(t, <-reloads)
+	assert.True(t, registry.HasPrompt("goodbye"))
+	assert.Len(t, registry.GetListOfPrompts(), 2)
+}
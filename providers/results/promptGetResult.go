@@ -111,11 +111,9 @@ func (r *PromptGetResultImpl) AddImageContent(role types.Role, mimeType string,
 	r.Messages = append(r.Messages, map[string]interface{}{
 		"role": role,
 		"content": map[string]interface{}{
-			"type": "resource",
-			"resource": map[string]interface{}{
-				"mimeType": mimeType,
-				"data":     base64Data,
-			},
+			"type":     "image",
+			"data":     base64Data,
+			"mimeType": mimeType,
 		},
 	})
 }
@@ -2,7 +2,9 @@ package results
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/llmcontext/gomcp/protocol/mcp"
 	"github.com/llmcontext/gomcp/types"
 )
 
@@ -64,11 +66,12 @@ export const BlobResourceContentsSchema = ResourceContentsSchema.extend({
 */
 
 type ToolCallResultImpl struct {
-	Content []interface{} `json:"content"`
-	IsError *bool         `json:"isError,omitempty"`
+	Content           []interface{} `json:"content"`
+	StructuredContent interface{}   `json:"structuredContent,omitempty"`
+	IsError           *bool         `json:"isError,omitempty"`
 }
 
-func NewToolCallResult() types.ToolCallResult {
+func NewToolCallResult() types.ExtendedToolCallResult {
 	return &ToolCallResultImpl{
 		Content: []interface{}{},
 		IsError: nil,
@@ -102,6 +105,14 @@ func (r *ToolCallResultImpl) AddImageContent(mimeType string, base64Data string)
 	})
 }
 
+func (r *ToolCallResultImpl) AddAudioContent(mimeType string, base64Data string) {
+	r.Content = append(r.Content, map[string]interface{}{
+		"type":     "audio",
+		"data":     base64Data,
+		"mimeType": mimeType,
+	})
+}
+
 func (r *ToolCallResultImpl) AddEmbeddedResourceTextContent(uri string, mimeType string, text string) {
 	r.Content = append(r.Content, map[string]interface{}{
 		"type": "resource",
@@ -127,3 +138,27 @@ func (r *ToolCallResultImpl) AddEmbeddedResourceBlobContent(uri string, mimeType
 func (r *ToolCallResultImpl) SetError(isError bool) {
 	r.IsError = &isError
 }
+
+func (r *ToolCallResultImpl) SetStructuredContent(content interface{}) {
+	r.StructuredContent = content
+	r.AddJSONTextContent(content)
+}
+
+// ApplyProtocolFeatures removes what the protocol version
+// negotiated with the client does not support
+func (r *ToolCallResultImpl) ApplyProtocolFeatures(features mcp.ProtocolFeatures) {
+	if !features.StructuredOutput {
+		// the JSON is also in a text content
+		r.StructuredContent = nil
+	}
+	if !features.AudioContent {
+		for index, content := range r.Content {
+			if content, ok := content.(map[string]interface{}); ok && content["type"] == "audio" {
+				r.Content[index] = map[string]interface{}{
+					"type": "text",
+					"text": fmt.Sprintf("(%s audio content not supported by the client)", content["mimeType"]),
+				}
+			}
+		}
+	}
+}
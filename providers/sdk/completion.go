@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/pkg/prompts"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// SetCompletionHandler sets the function suggesting the values of the
+// arguments of the prompts and resource templates, eg. the names of the
+// projects. Without handler, the values declared with the prompt arguments
+// are suggested
+func (s *SdkServerDefinition) SetCompletionHandler(handler types.CompletionHandler) {
+	s.completionHandler = handler
+}
+
+func (s *SdkServerDefinition) ExecuteCompletion(
+	ctx context.Context,
+	params *mcp.JsonRpcRequestCompletionCompleteParams,
+	logger types.Logger,
+) (*mcp.JsonRpcResponseCompletionCompleteResult, *jsonrpc.JsonRpcError) {
+	request := &types.CompletionRequest{
+		PromptName:   params.Ref.Name,
+		ResourceUri:  params.Ref.Uri,
+		ArgumentName: params.Argument.Name,
+		Value:        params.Argument.Value,
+	}
+
+	// the static values of the prompt argument
+	var argument *prompts.PromptArgumentDefinition
+	if params.Ref.Type == mcp.CompletionRefPrompt {
+		prompt := s.findPrompt(params.Ref.Name)
+		if prompt == nil {
+			return nil, &jsonrpc.JsonRpcError{
+				Code:    jsonrpc.RpcInvalidParams,
+				Message: fmt.Sprintf("prompt %s not found", params.Ref.Name),
+			}
+		}
+		for i := range prompt.Arguments {
+			if prompt.Arguments[i].Name == params.Argument.Name {
+				argument = &prompt.Arguments[i]
+			}
+		}
+		if argument == nil {
+			return nil, &jsonrpc.JsonRpcError{
+				Code:    jsonrpc.RpcInvalidParams,
+				Message: fmt.Sprintf("prompt %s has no argument %s", params.Ref.Name, params.Argument.Name),
+			}
+		}
+	}
+
+	var values []string
+	if s.completionHandler != nil {
+		var err error
+		values, err = s.completionHandler(types.ContextWithLogger(ctx, logger), request)
+		if err != nil {
+			logger.Error("completion handler failed", types.LogArg{
+				"argument": params.Argument.Name,
+				"error":    err,
+			})
+			return nil, &jsonrpc.JsonRpcError{
+				Code:    jsonrpc.RpcInternalError,
+				Message: fmt.Sprintf("completion error: %v", err),
+			}
+		}
+	}
+	if values == nil && argument != nil {
+		values = completeValues(argument.Values, params.Argument.Value)
+	}
+
+	return completionResult(values), nil
+}
+
+// findPrompt returns the prompt of the prompts files or declared with AddPrompt
+func (s *SdkServerDefinition) findPrompt(promptName string) *prompts.PromptDefinition {
+	for _, prompt := range s.GetListOfPrompts() {
+		if prompt.Name == promptName {
+			return prompt
+		}
+	}
+	return nil
+}
+
+// completeValues returns the values starting with what the user typed, ignoring the case
+func completeValues(values []string, typed string) []string {
+	completed := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), strings.ToLower(typed)) {
+			completed = append(completed, value)
+		}
+	}
+	return completed
+}
+
+func completionResult(values []string) *mcp.JsonRpcResponseCompletionCompleteResult {
+	total := len(values)
+	hasMore := total > mcp.CompletionMaxValues
+	if hasMore {
+		values = values[:mcp.CompletionMaxValues]
+	}
+	if values == nil {
+		values = []string{}
+	}
+	return &mcp.JsonRpcResponseCompletionCompleteResult{
+		Completion: mcp.CompletionValues{
+			Values:  values,
+			Total:   &total,
+			HasMore: &hasMore,
+		},
+	}
+}
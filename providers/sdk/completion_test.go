@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const translatePrompts = `prompts:
+  - name: translate
+    description: Translates a text
+    arguments:
+      - name: language
+        description: the target language
+        required: true
+        values: [English, French, Finnish, German]
+      - name: text
+        description: the text to translate
+        required: true
+    prompt: Translate {{.text}} to {{.language}}
+`
+
+func complete(definition *SdkServerDefinition, ref mcp.CompletionReference, argument string, value string) (*mcp.JsonRpcResponseCompletionCompleteResult, *jsonrpc.JsonRpcError) {
+	params := &mcp.JsonRpcRequestCompletionCompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompletionArgument{Name: argument, Value: value},
+	}
+	return definition.ExecuteCompletion(context.Background(), params, logger.NewTeeLogger())
+}
+
+func TestExecuteCompletion(t *testing.T) {
+	promptsFile := filepath.Join(t.TempDir(), "prompts.yaml")
+	assert.NoError(t, os.WriteFile(promptsFile, []byte(translatePrompts), 0644))
+
+	definition := NewMcpSdkServerDefinition("test", "0.1.0")
+	_, err := definition.AddTemplateYamlFile(promptsFile)
+	assert.NoError(t, err)
+	translate := mcp.CompletionReference{Type: mcp.CompletionRefPrompt, Name: "translate"}
+
+	// the static values
+	result, rpcErr := complete(definition, translate, "language", "f")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, []string{"French", "Finnish"}, result.Completion.Values)
+	assert.Equal(t, 2, *result.Completion.Total)
+	assert.False(t, *result.Completion.HasMore)
+
+	result, _ = complete(definition, translate, "text", "hel")
+	assert.Equal(t, []string{}, result.Completion.Values)
+
+	_, rpcErr = complete(definition, translate, "tone", "")
+	if assert.NotNil(t, rpcErr) {
+		assert.Equal(t, "prompt translate has no argument tone", rpcErr.Message)
+	}
+
+	// the handler takes precedence, unless it returns nil
+	definition.SetCompletionHandler(func(ctx context.Context, request *types.CompletionRequest) ([]string, error) {
+		if request.ArgumentName == "text" {
+			return []string{request.Value + "lo"}, nil
+		}
+		return nil, nil
+	})
+	result, _ = complete(definition, translate, "text", "hel")
+	assert.Equal(t, []string{"hello"}, result.Completion.Values)
+	result, _ = complete(definition, translate, "language", "G")
+	assert.Equal(t, []string{"German"}, result.Completion.Values)
+}
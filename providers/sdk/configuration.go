@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/pkg/config"
+	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// NewMcpSdkServerDefinitionFromConfiguration builds a server definition from
+// the content of a configuration file. Each tool provider is matched by name
+// with an entry of the tools section, the configuration of that entry is
+// validated against the JSON schema of the provider configuration type.
+func NewMcpSdkServerDefinitionFromConfiguration(
+	serverConfig *config.ServerConfiguration,
+	toolProviders ...types.ToolProvider,
+) (*SdkServerDefinition, error) {
+	s := NewMcpSdkServerDefinition(serverConfig.ServerInfo.Name, serverConfig.ServerInfo.Version)
+
+	if serverConfig.Logging != nil {
+		s.SetDebugLevel(serverConfig.Logging.Level, serverConfig.Logging.File)
+		s.SetDebugWithStderr(serverConfig.Logging.WithStderr)
+
+		rotation := &logger.RotationInfo{
+			MaxSize:    serverConfig.Logging.MaxSize,
+			MaxBackups: serverConfig.Logging.MaxBackups,
+			Compress:   serverConfig.Logging.Compress,
+		}
+		if serverConfig.Logging.MaxAge != "" {
+			maxAge, err := time.ParseDuration(serverConfig.Logging.MaxAge)
+			if err != nil {
+				return nil, fmt.Errorf("invalid logging.maxAge: %v", err)
+			}
+			rotation.MaxAge = maxAge
+		}
+		s.SetDebugFileRotation(serverConfig.Logging.Append, rotation)
+		s.SetProtocolDebugFile(serverConfig.Logging.ProtocolDebugFile)
+	}
+
+	if serverConfig.Inspector != nil && serverConfig.Inspector.Enabled {
+		s.EnableInspector(serverConfig.Inspector.ListenAddress)
+	}
+
+	if serverConfig.Pagination != nil {
+		s.SetListPageSize(serverConfig.Pagination.PageSize)
+	}
+
+	if serverConfig.Prompts != nil && serverConfig.Prompts.File != "" {
+		duplicatedPrompts, err := s.AddTemplateYamlFile(serverConfig.Prompts.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prompts file %s: %v", serverConfig.Prompts.File, err)
+		}
+		if len(duplicatedPrompts) > 0 {
+			return nil, fmt.Errorf("duplicated prompt %s in %s", duplicatedPrompts[0].PromptName, duplicatedPrompts[0].FilePath)
+		}
+		if serverConfig.Prompts.Watch {
+			s.WatchPromptFiles(0)
+		}
+	}
+
+	// index the tools configuration by provider name
+	toolsConfig := make(map[string]*config.ToolProviderConfiguration)
+	for _, toolConfig := range serverConfig.Tools {
+		if _, found := toolsConfig[toolConfig.Name]; found {
+			return nil, fmt.Errorf("tool provider %s is configured more than once", toolConfig.Name)
+		}
+		toolsConfig[toolConfig.Name] = toolConfig
+	}
+
+	registered := make(map[string]bool)
+	for _, toolProvider := range toolProviders {
+		provider, ok := toolProvider.(*SdkToolProvider)
+		if !ok {
+			return nil, fmt.Errorf("invalid tool provider type: expected *sdk.SdkToolProvider, got %T", toolProvider)
+		}
+		if registered[provider.name] {
+			return nil, fmt.Errorf("tool provider %s is registered more than once", provider.name)
+		}
+		registered[provider.name] = true
+
+		toolConfig := toolsConfig[provider.name]
+		configurationType := provider.ConfigurationType()
+		if configurationType == nil {
+			// the init function does not expect any configuration,
+			// we don't want to silently ignore the one given
+			if toolConfig != nil && toolConfig.Configuration != nil {
+				return nil, fmt.Errorf("tool provider %s does not take any configuration", provider.name)
+			}
+			s.AddToolProvider(provider, nil)
+			continue
+		}
+
+		if toolConfig == nil || toolConfig.Configuration == nil {
+			return nil, fmt.Errorf("missing configuration for tool provider %s", provider.name)
+		}
+		configurationData, err := parseToolConfiguration(configurationType, toolConfig.Configuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for tool provider %s: %v", provider.name, err)
+		}
+		s.AddToolProvider(provider, configurationData)
+	}
+
+	// we don't want to silently ignore a configuration
+	for _, toolConfig := range serverConfig.Tools {
+		if !registered[toolConfig.Name] {
+			return nil, fmt.Errorf("no tool provider registered for %s", toolConfig.Name)
+		}
+	}
+
+	return s, nil
+}
+
+// parseToolConfiguration checks the raw configuration against the schema
+// reflected from the configuration type and returns a pointer to a new
+// instance of that type filled with the configuration
+func parseToolConfiguration(configurationType reflect.Type, rawConfiguration interface{}) (interface{}, error) {
+	if configurationType.Kind() != reflect.Ptr || configurationType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configuration type must be a pointer to a struct, got %s", configurationType.String())
+	}
+
+	schema, _, err := jsonschema.GetFullSchemaFromInterface(configurationType)
+	if err != nil {
+		return nil, fmt.Errorf("error generating schema for %s: %v", configurationType.String(), err)
+	}
+
+	err = jsonschema.ValidateJsonSchemaWithObject(schema, rawConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := json.Marshal(rawConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	configurationData := reflect.New(configurationType.Elem()).Interface()
+	if err := json.Unmarshal(jsonBytes, configurationData); err != nil {
+		return nil, err
+	}
+
+	return configurationData, nil
+}
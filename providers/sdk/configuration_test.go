@@ -0,0 +1,113 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/llmcontext/gomcp/pkg/config"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type notionConfiguration struct {
+	NotionToken string `json:"notionToken" jsonschema_description:"the notion token."`
+}
+
+type notionContext struct {
+	token string
+}
+
+func notionInit(ctx context.Context, config *notionConfiguration) (*notionContext, error) {
+	return &notionContext{token: config.NotionToken}, nil
+}
+
+type notionInput struct {
+	PageId string `json:"pageId"`
+}
+
+func notionGetPage(ctx context.Context, toolCtx *notionContext, input *notionInput, output types.ToolCallResult) error {
+	output.AddTextContent(toolCtx.token)
+	return nil
+}
+
+func newNotionProvider() types.ToolProvider {
+	provider := NewToolProvider("notion", notionInit)
+	provider.AddTool("notion_get_page", "Get a notion page", notionGetPage)
+	return provider
+}
+
+func TestServerDefinitionFromConfiguration(t *testing.T) {
+	serverConfig, err := config.ParseServerConfiguration([]byte(`{
+		"serverInfo": {"name": "gomcp", "version": "0.1.0"},
+		"logging": {"file": "/tmp/gomcp.log", "level": "debug"},
+		"tools": [{"name": "notion", "configuration": {"notionToken": "ntn_123"}}]
+	}`))
+	assert.NoError(t, err)
+
+	s, err := NewMcpSdkServerDefinitionFromConfiguration(serverConfig, newNotionProvider())
+	assert.NoError(t, err)
+	assert.NoError(t, s.Prepare())
+
+	assert.Equal(t, "gomcp", s.ServerName())
+	assert.Equal(t, "debug", s.DebugLevel())
+	assert.Len(t, s.GetListOfTools(), 1)
+
+	configuration, ok := s.GetToolProviders()[0].toolConfigurationData.(*notionConfiguration)
+	assert.True(t, ok)
+	assert.Equal(t, "ntn_123", configuration.NotionToken)
+}
+
+func TestServerDefinitionFromInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "configuration does not match the schema",
+			config:  `{"serverInfo": {"name": "gomcp", "version": "0.1.0"}, "tools": [{"name": "notion", "configuration": {"token": "ntn_123"}}]}`,
+			wantErr: "invalid configuration for tool provider notion",
+		},
+		{
+			name:    "missing configuration",
+			config:  `{"serverInfo": {"name": "gomcp", "version": "0.1.0"}}`,
+			wantErr: "missing configuration for tool provider notion",
+		},
+		{
+			name:    "unknown tool provider",
+			config:  `{"serverInfo": {"name": "gomcp", "version": "0.1.0"}, "tools": [{"name": "notion", "configuration": {"notionToken": "ntn_123"}}, {"name": "jira", "configuration": {}}]}`,
+			wantErr: "no tool provider registered for jira",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig, err := config.ParseServerConfiguration([]byte(tt.config))
+			assert.NoError(t, err)
+
+			_, err = NewMcpSdkServerDefinitionFromConfiguration(serverConfig, newNotionProvider())
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestServerDefinitionWithUnexpectedConfiguration(t *testing.T) {
+	serverConfig, err := config.ParseServerConfiguration([]byte(`{
+		"serverInfo": {"name": "gomcp", "version": "0.1.0"},
+		"tools": [{"name": "tracker", "configuration": {"token": "abc"}}]
+	}`))
+	assert.NoError(t, err)
+
+	// the init function of the provider takes no configuration
+	_, err = NewMcpSdkServerDefinitionFromConfiguration(serverConfig, NewToolProvider("tracker", trackerInit))
+	assert.ErrorContains(t, err, "tool provider tracker does not take any configuration")
+
+	// an entry without configuration is accepted
+	serverConfig, err = config.ParseServerConfiguration([]byte(`{
+		"serverInfo": {"name": "gomcp", "version": "0.1.0"},
+		"tools": [{"name": "tracker"}]
+	}`))
+	assert.NoError(t, err)
+	_, err = NewMcpSdkServerDefinitionFromConfiguration(serverConfig, NewToolProvider("tracker", trackerInit))
+	assert.NoError(t, err)
+}
@@ -1,30 +1,52 @@
 package sdk
 
 import (
-	"reflect"
+	"context"
+	"io/fs"
 	"slices"
+	"text/template"
+	"time"
 
 	"github.com/invopop/jsonschema"
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/pkg/pagination"
 	"github.com/llmcontext/gomcp/pkg/prompts"
 	"github.com/llmcontext/gomcp/providers/registry"
 	"github.com/llmcontext/gomcp/types"
 )
 
+// DefaultInspectorAddress only accepts local connections, the inspector
+// can call any tool and must not be exposed
+const DefaultInspectorAddress = "127.0.0.1:8090"
+
+// DefaultPromptsWatchInterval is the delay between two checks
+// of the prompts files when they are watched
+const DefaultPromptsWatchInterval = time.Second
+
 type SdkServerDefinition struct {
-	serverName            string
-	serverVersion         string
-	debugLevel            string
-	debugFile             string
-	toolConfigurationData interface{}
-	toolsInitFunction     interface{}
-	toolDefinitions       []*SdkToolDefinition
-	promptsRegistry       *registry.PromptsRegistry
+	serverName        string
+	serverVersion     string
+	debugLevel        string
+	debugFile         string
+	debugWithStderr   bool
+	debugAppend       bool
+	debugRotation     *logger.RotationInfo
+	protocolDebugFile string
+	inspectorAddress  string
+	logger            types.Logger
+	toolProviders     []*SdkToolProvider
+	promptsRegistry   *registry.PromptsRegistry
+	promptDefinitions []*SdkPromptDefinition
+	// suggests the values of the arguments, may be nil
+	completionHandler types.CompletionHandler
+	// the number of items of a page of tools/list and prompts/list
+	listPageSize int
+	// the prompts files are not watched if 0
+	promptsWatchInterval time.Duration
 
-	// enhanced data
-	contextType     reflect.Type
-	contextTypeName string
-	// the tool context retrieve from the tool init function
-	toolContext interface{}
+	// backoff applied when a tools init function fails
+	initMinBackoff time.Duration
+	initMaxBackoff time.Duration
 }
 
 type SdkToolDefinition struct {
@@ -32,6 +54,8 @@ type SdkToolDefinition struct {
 	ToolDescription     string
 	toolHandlerFunction interface{}
 
+	// the provider declaring the tool
+	provider *SdkToolProvider
 	// from the server context
 	toolContext interface{}
 
@@ -41,12 +65,19 @@ type SdkToolDefinition struct {
 }
 
 func NewMcpSdkServerDefinition(serverName string, serverVersion string) *SdkServerDefinition {
-	return &SdkServerDefinition{
+	definition := &SdkServerDefinition{
 		serverName:      serverName,
 		serverVersion:   serverVersion,
-		toolDefinitions: []*SdkToolDefinition{},
+		toolProviders:   []*SdkToolProvider{},
 		promptsRegistry: registry.NewPromptsRegistry(),
+		listPageSize:    pagination.DefaultPageSize,
+		initMinBackoff:  defaultInitMinBackoff,
+		initMaxBackoff:  defaultInitMaxBackoff,
 	}
+	definition.promptsRegistry.ReserveNames(func(name string) bool {
+		return definition.GetPromptDefinition(name) != nil
+	})
+	return definition
 }
 
 func NewMcpServerDefinition(serverName string, serverVersion string) types.McpSdkServerDefinition {
@@ -70,6 +101,20 @@ func (s *SdkServerDefinition) SetDebugLevel(debugLevel string, debugFile string)
 	s.debugFile = debugFile
 }
 
+func (s *SdkServerDefinition) SetDebugWithStderr(withStderr bool) {
+	s.debugWithStderr = withStderr
+}
+
+// SetLogger sets the logger used by the server, the debug level
+// and file are ignored when a logger is provided
+func (s *SdkServerDefinition) SetLogger(logger types.Logger) {
+	s.logger = logger
+}
+
+func (s *SdkServerDefinition) Logger() types.Logger {
+	return s.logger
+}
+
 func (s *SdkServerDefinition) DebugLevel() string {
 	validLevels := []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
 	if !slices.Contains(validLevels, s.debugLevel) {
@@ -85,42 +130,148 @@ func (s *SdkServerDefinition) DebugFile() string {
 	return s.debugFile
 }
 
+func (s *SdkServerDefinition) DebugWithStderr() bool {
+	return s.debugWithStderr
+}
+
+// SetDebugFileRotation keeps the content of an existing log file
+// if appendMode is set, and rotates the log file as defined by rotation
+func (s *SdkServerDefinition) SetDebugFileRotation(appendMode bool, rotation *logger.RotationInfo) {
+	s.debugAppend = appendMode
+	s.debugRotation = rotation
+}
+
+func (s *SdkServerDefinition) DebugAppend() bool {
+	return s.debugAppend
+}
+
+func (s *SdkServerDefinition) DebugRotation() *logger.RotationInfo {
+	return s.debugRotation
+}
+
+// SetProtocolDebugFile records all the JSON-RPC messages
+// exchanged with the client in a JSONL file
+func (s *SdkServerDefinition) SetProtocolDebugFile(protocolDebugFile string) {
+	s.protocolDebugFile = protocolDebugFile
+}
+
+func (s *SdkServerDefinition) ProtocolDebugFile() string {
+	return s.protocolDebugFile
+}
+
+// EnableInspector serves the web inspector on listenAddress,
+// DefaultInspectorAddress is used if it is empty
+func (s *SdkServerDefinition) EnableInspector(listenAddress string) {
+	if listenAddress == "" {
+		listenAddress = DefaultInspectorAddress
+	}
+	s.inspectorAddress = listenAddress
+}
+
+// InspectorAddress returns an empty string if the inspector is disabled
+func (s *SdkServerDefinition) InspectorAddress() string {
+	return s.inspectorAddress
+}
+
+// SetListPageSize sets the number of tools or prompts returned by a list
+// request, the client uses the cursor of the response to get the next ones.
+// All the items are returned at once if pageSize is 0
+func (s *SdkServerDefinition) SetListPageSize(pageSize int) {
+	s.listPageSize = pageSize
+}
+
+func (s *SdkServerDefinition) ListPageSize() int {
+	return s.listPageSize
+}
+
+// SetToolsInitBackoff sets the delay before retrying a failed call
+// to the tools init function. The delay starts at minBackoff and doubles
+// after each consecutive failure, up to maxBackoff.
+func (s *SdkServerDefinition) SetToolsInitBackoff(minBackoff time.Duration, maxBackoff time.Duration) {
+	s.initMinBackoff = minBackoff
+	s.initMaxBackoff = maxBackoff
+}
+
 func (s *SdkServerDefinition) WithTools(toolConfigurationDate interface{}, toolsInitFunction interface{}) types.ToolsDefinition {
-	s.toolConfigurationData = toolConfigurationDate
-	s.toolsInitFunction = toolsInitFunction
-	return s
+	provider := NewSdkToolProvider("", toolsInitFunction)
+	s.AddToolProvider(provider, toolConfigurationDate)
+	return provider
 }
 
-func (s *SdkServerDefinition) AddTool(toolName string, description string, toolHandler interface{}) error {
-	s.toolDefinitions = append(s.toolDefinitions, &SdkToolDefinition{
-		ToolName:            toolName,
-		ToolDescription:     description,
-		toolHandlerFunction: toolHandler,
-	})
-	return nil
+// AddToolProvider registers a tool provider with the configuration
+// data passed to its init function
+func (s *SdkServerDefinition) AddToolProvider(provider *SdkToolProvider, toolConfigurationData interface{}) {
+	provider.toolConfigurationData = toolConfigurationData
+	s.toolProviders = append(s.toolProviders, provider)
+}
+
+func (s *SdkServerDefinition) GetToolProviders() []*SdkToolProvider {
+	return s.toolProviders
 }
 
 func (s *SdkServerDefinition) GetListOfTools() []*SdkToolDefinition {
-	return s.toolDefinitions
+	tools := []*SdkToolDefinition{}
+	for _, provider := range s.toolProviders {
+		tools = append(tools, provider.toolDefinitions...)
+	}
+	return tools
 }
 
 func (s *SdkServerDefinition) GetTool(toolName string) *SdkToolDefinition {
-	for _, tool := range s.toolDefinitions {
-		if tool.ToolName == toolName {
-			return tool
+	for _, provider := range s.toolProviders {
+		for _, tool := range provider.toolDefinitions {
+			if tool.ToolName == toolName {
+				return tool
+			}
 		}
 	}
 	return nil
 }
 
+// GetListOfPrompts returns the prompts of the prompts files
+// followed by the ones declared with AddPrompt
 func (s *SdkServerDefinition) GetListOfPrompts() []*prompts.PromptDefinition {
-	return s.promptsRegistry.GetListOfPrompts()
+	list := append([]*prompts.PromptDefinition{}, s.promptsRegistry.GetListOfPrompts()...)
+	for _, prompt := range s.promptDefinitions {
+		list = append(list, prompt.promptDefinition())
+	}
+	return list
 }
 
 func (s *SdkServerDefinition) GetPrompt(promptName string, arguments map[string]string) (types.PromptGetResult, error) {
 	return s.promptsRegistry.GetPrompt(promptName, arguments)
 }
 
+// AddPromptFuncs makes Go functions available to the prompt templates
+func (s *SdkServerDefinition) AddPromptFuncs(funcs template.FuncMap) {
+	s.promptsRegistry.AddFuncs(funcs)
+}
+
 func (s *SdkServerDefinition) AddTemplateYamlFile(templateYamlFilePath string) ([]*prompts.DuplicatedPrompt, error) {
 	return s.promptsRegistry.LoadPromptYamlFile(templateYamlFilePath)
 }
+
+// WatchPromptFiles reloads the files added with AddTemplateYamlFile when
+// they change, they are checked every interval (DefaultPromptsWatchInterval
+// if 0). The clients are notified that the list of prompts changed
+func (s *SdkServerDefinition) WatchPromptFiles(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPromptsWatchInterval
+	}
+	s.promptsWatchInterval = interval
+}
+
+// WatchPrompts watches the prompts files until ctx is done, see WatchPromptFiles.
+// It returns immediately if the files are not watched
+func (s *SdkServerDefinition) WatchPrompts(ctx context.Context, onReload func(duplicatedPrompts []*prompts.DuplicatedPrompt, err error)) {
+	if s.promptsWatchInterval == 0 {
+		return
+	}
+	s.promptsRegistry.Watch(ctx, s.promptsWatchInterval, onReload)
+}
+
+// AddTemplateFS loads the prompts files of fsys matching pattern, eg. an embed.FS
+// built into the server binary, Markdown files with a front-matter are supported
+func (s *SdkServerDefinition) AddTemplateFS(fsys fs.FS, pattern string) ([]*prompts.DuplicatedPrompt, error) {
+	return s.promptsRegistry.LoadPromptFS(fsys, pattern)
+}
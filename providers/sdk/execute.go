@@ -10,9 +10,12 @@ import (
 	"github.com/llmcontext/gomcp/types"
 )
 
+// ExecuteToolCall calls the tool, the initialization of the tool context
+// is logged on serverLogger and the tool logs on logger
 func (n *SdkServerDefinition) ExecuteToolCall(
 	ctx context.Context,
 	params *mcp.JsonRpcRequestToolsCallParams,
+	serverLogger types.Logger,
 	logger types.Logger,
 ) (types.ToolCallResult, *jsonrpc.JsonRpcError) {
 	toolName := params.Name
@@ -25,42 +28,127 @@ func (n *SdkServerDefinition) ExecuteToolCall(
 		}
 	}
 
-	// check if we already have a context for this tool
-	if tool.toolContext == nil {
-		// if not, we initialize the tool context
-		err := n.serverInitFunction(ctx, logger)
-		if err != nil {
-			logger.Error("error initializing tool context", types.LogArg{
-				"toolName": toolName,
-				"error":    err,
-			})
-			return nil, &jsonrpc.JsonRpcError{
-				Code:    jsonrpc.RpcInternalError,
-				Message: fmt.Sprintf("tool %s - error initializing tool context: %v", toolName, err),
-			}
+	// make sure the tool context is initialized
+	// this is a no-op if it was already done
+	err := tool.provider.ensureToolContext(ctx, serverLogger)
+	if err != nil {
+		logger.Error("error initializing tool context", types.LogArg{
+			"toolName": toolName,
+			"error":    err,
+		})
+		return nil, &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInternalError,
+			Message: fmt.Sprintf("tool %s - error initializing tool context: %v", toolName, err),
 		}
 	}
 
 	// let's create the output
 	output := results.NewToolCallResult()
 
+	rpcErr := runHandler(ctx, func() *jsonrpc.JsonRpcError {
+		return tool.toolProcessFunction(ctx, arguments, output, logger)
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return output, nil
+}
+
+// ExecutePromptGet calls the handler of a prompt declared with AddPrompt,
+// the loggers are the ones of ExecuteToolCall
+func (n *SdkServerDefinition) ExecutePromptGet(
+	ctx context.Context,
+	params *mcp.JsonRpcRequestPromptsGetParams,
+	serverLogger types.Logger,
+	logger types.Logger,
+) (types.PromptGetResult, *jsonrpc.JsonRpcError) {
+	promptName := params.Name
+	prompt := n.GetPromptDefinition(promptName)
+	if prompt == nil {
+		return nil, &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInvalidParams,
+			Message: fmt.Sprintf("prompt %s not found", promptName),
+		}
+	}
+
+	// the handler receives a struct, converted from the arguments
+	arguments := make(map[string]interface{})
+	for _, argument := range prompt.Arguments {
+		value, ok := params.Arguments[argument.Name]
+		if !ok {
+			if argument.Required {
+				return nil, &jsonrpc.JsonRpcError{
+					Code:    jsonrpc.RpcInvalidParams,
+					Message: fmt.Sprintf("prompt processing error: missing argument: %s", argument.Name),
+				}
+			}
+			continue
+		}
+		arguments[argument.Name] = value
+	}
+
+	// make sure the tool context is initialized
+	// this is a no-op if it was already done
+	err := prompt.provider.ensureToolContext(ctx, serverLogger)
+	if err != nil {
+		logger.Error("error initializing tool context", types.LogArg{
+			"promptName": promptName,
+			"error":      err,
+		})
+		return nil, &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInternalError,
+			Message: fmt.Sprintf("prompt %s - error initializing tool context: %v", promptName, err),
+		}
+	}
+
+	// let's create the output
+	output := results.NewPromptGetResult(prompt.PromptDescription)
+
+	rpcErr := runHandler(ctx, func() *jsonrpc.JsonRpcError {
+		goCtx := types.ContextWithLogger(ctx, logger)
+		_, callErr, err := callFunction(prompt.promptHandlerFunction, goCtx, prompt.provider.toolContext, arguments, output)
+		return handlerError(callErr, err)
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return output, nil
+}
+
+// runHandler calls handler in its own goroutine, so that the request
+// returns when ctx is done even if the handler does not check it
+func runHandler(ctx context.Context, handler func() *jsonrpc.JsonRpcError) *jsonrpc.JsonRpcError {
 	errChan := make(chan *jsonrpc.JsonRpcError, 1)
 	go func() {
-		tool.toolProcessFunction(ctx, arguments, output, logger, errChan)
+		errChan <- handler()
 	}()
 
 	// wait on context and errChan
 	select {
 	case err := <-errChan:
-		if err != nil {
-			return nil, err
-		} else {
-			return output, nil
-		}
+		return err
 	case <-ctx.Done():
-		return nil, &jsonrpc.JsonRpcError{
+		return &jsonrpc.JsonRpcError{
 			Code:    jsonrpc.RpcInternalError,
 			Message: ctx.Err().Error(),
 		}
 	}
 }
+
+// handlerError converts the errors returned by callFunction, err is set
+// if the handler could not be called, callErr is the one it returned
+func handlerError(callErr error, err error) *jsonrpc.JsonRpcError {
+	if err != nil {
+		return &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInternalError,
+			Message: err.Error(),
+		}
+	}
+	if callErr != nil {
+		return &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInternalError,
+			Message: callErr.Error(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/llmcontext/gomcp/types"
+)
+
+const (
+	defaultInitMinBackoff = 1 * time.Second
+	defaultInitMaxBackoff = 1 * time.Minute
+	// the init function does not run on the context of the tool call,
+	// a cancelled call must not fail the initialization of the others
+	defaultInitTimeout = 1 * time.Minute
+)
+
+// toolContextInitializer makes sure the tools init function is called
+// only once, even when several tool calls arrive concurrently.
+// If the init function fails, the error is returned to every caller until
+// the backoff delay expires, the next call then retries the initialization.
+// The delay doubles after each consecutive failure, up to maxBackoff.
+type toolContextInitializer struct {
+	mu          sync.Mutex
+	initialized bool
+	// the initialization in progress, nil if there is none
+	attempt     *initAttempt
+	failures    int
+	lastErr     error
+	nextAttempt time.Time
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+// initAttempt is closed when the init function returns
+type initAttempt struct {
+	done chan struct{}
+	err  error
+}
+
+func newToolContextInitializer() *toolContextInitializer {
+	return &toolContextInitializer{
+		minBackoff: defaultInitMinBackoff,
+		maxBackoff: defaultInitMaxBackoff,
+		timeout:    defaultInitTimeout,
+		now:        time.Now,
+	}
+}
+
+func (i *toolContextInitializer) setBackoff(minBackoff time.Duration, maxBackoff time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if minBackoff < 0 {
+		minBackoff = 0
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+	i.minBackoff = minBackoff
+	i.maxBackoff = maxBackoff
+}
+
+// run calls initFunction unless a previous call already succeeded.
+// initFunction runs in its own goroutine, on a context detached from ctx
+// with its own timeout. The concurrent callers wait for the outcome of the
+// same attempt, or until their own ctx is done.
+func (i *toolContextInitializer) run(ctx context.Context, initFunction func(ctx context.Context) error) error {
+	i.mu.Lock()
+	if i.initialized {
+		i.mu.Unlock()
+		return nil
+	}
+
+	attempt := i.attempt
+	if attempt == nil {
+		// a previous attempt failed, we wait for the backoff to expire
+		if i.lastErr != nil && i.now().Before(i.nextAttempt) {
+			err := fmt.Errorf("initialization failed, next attempt in %s: %w",
+				i.nextAttempt.Sub(i.now()).Round(time.Millisecond), i.lastErr)
+			i.mu.Unlock()
+			return err
+		}
+		attempt = &initAttempt{done: make(chan struct{})}
+		i.attempt = attempt
+		go i.initialize(context.WithoutCancel(ctx), attempt, initFunction)
+	}
+	i.mu.Unlock()
+
+	select {
+	case <-attempt.done:
+		return attempt.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (i *toolContextInitializer) initialize(ctx context.Context, attempt *initAttempt, initFunction func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+	err := initFunction(ctx)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if err != nil {
+		i.failures++
+		i.lastErr = err
+		i.nextAttempt = i.now().Add(i.backoff())
+	} else {
+		i.initialized = true
+		i.failures = 0
+		i.lastErr = nil
+	}
+	i.attempt = nil
+	attempt.err = err
+	close(attempt.done)
+}
+
+// backoff returns the delay before the next attempt,
+// based on the number of consecutive failures
+func (i *toolContextInitializer) backoff() time.Duration {
+	delay := i.minBackoff
+	for n := 1; n < i.failures; n++ {
+		delay *= 2
+		if delay >= i.maxBackoff {
+			return i.maxBackoff
+		}
+	}
+	if delay > i.maxBackoff {
+		return i.maxBackoff
+	}
+	return delay
+}
+
+// ensureToolContext initializes the tool context if needed
+// it is safe to call it from concurrent tool calls. The logger is the
+// server one, the initialization is not reported to the client
+func (s *SdkToolProvider) ensureToolContext(ctx context.Context, logger types.Logger) error {
+	return s.toolsInitializer.run(ctx, func(initCtx context.Context) error {
+		return s.serverInitFunction(initCtx, logger)
+	})
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/secrets"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolContextInitializerRunsOnce(t *testing.T) {
+	initializer := newToolContextInitializer()
+
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := initializer.run(context.Background(), func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestToolContextInitializerRetriesAfterBackoff(t *testing.T) {
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	initializer := newToolContextInitializer()
+	initializer.now = func() time.Time { return now }
+	initializer.setBackoff(time.Second, 3*time.Second)
+
+	calls := 0
+	failing := func(ctx context.Context) error {
+		calls++
+		return errors.New("token exchange failed")
+	}
+
+	// first attempt fails
+	assert.Error(t, initializer.run(context.Background(), failing))
+	assert.Equal(t, 1, calls)
+
+	// within the backoff window, the init function is not called
+	err := initializer.run(context.Background(), failing)
+	assert.ErrorContains(t, err, "token exchange failed")
+	assert.Equal(t, 1, calls)
+
+	// after the backoff, we retry and the delay doubles
+	now = now.Add(time.Second)
+	assert.Error(t, initializer.run(context.Background(), failing))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2*time.Second, initializer.nextAttempt.Sub(now))
+
+	// the delay is capped by the max backoff
+	now = now.Add(2 * time.Second)
+	assert.Error(t, initializer.run(context.Background(), failing))
+	assert.Equal(t, 3*time.Second, initializer.nextAttempt.Sub(now))
+
+	// a successful attempt is final
+	now = now.Add(3 * time.Second)
+	assert.NoError(t, initializer.run(context.Background(), func(ctx context.Context) error { calls++; return nil }))
+	assert.NoError(t, initializer.run(context.Background(), failing))
+	assert.Equal(t, 4, calls)
+}
+
+func TestToolContextInitializerDetachedFromCaller(t *testing.T) {
+	initializer := newToolContextInitializer()
+	release := make(chan struct{})
+	initFunction := func(ctx context.Context) error {
+		<-release
+		// the cancellation of the first caller does not reach the init function
+		return ctx.Err()
+	}
+
+	// the first caller gives up, the initialization goes on
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, initializer.run(ctx, initFunction), context.Canceled)
+
+	// a waiter is not blocked by the attempt in progress
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, initializer.run(ctx, initFunction), context.DeadlineExceeded)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- initializer.run(context.Background(), initFunction)
+	}()
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, initializer.run(context.Background(), initFunction))
+}
+
+type credentialsContext struct {
+	Workspace string
+	Token     string `gomcp:"secret"`
+}
+
+// recordingLogger keeps the fields of the info entries
+type recordingLogger struct {
+	entries []types.LogArg
+}
+
+func (l *recordingLogger) Info(message string, fields types.LogArg) {
+	l.entries = append(l.entries, fields)
+}
+func (l *recordingLogger) Debug(message string, fields types.LogArg) {}
+func (l *recordingLogger) Error(message string, fields types.LogArg) {}
+func (l *recordingLogger) Fatal(message string, fields types.LogArg) {}
+
+func TestInitLogRedactsToolContext(t *testing.T) {
+	provider := NewSdkToolProvider("crm", func(ctx context.Context) (*credentialsContext, error) {
+		return &credentialsContext{Workspace: "acme", Token: "tok_123"}, nil
+	})
+
+	recorder := &recordingLogger{}
+	assert.NoError(t, provider.ensureToolContext(context.Background(), recorder))
+	if assert.Len(t, recorder.entries, 1) {
+		logged := recorder.entries[0]["result"].(*credentialsContext)
+		assert.Equal(t, "acme", logged.Workspace)
+		assert.Equal(t, secrets.RedactedValue, logged.Token)
+	}
+	// the tool context itself is untouched
+	assert.Equal(t, "tok_123", provider.toolContext.(*credentialsContext).Token)
+}
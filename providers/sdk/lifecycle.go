@@ -5,10 +5,11 @@ import (
 
 	"github.com/llmcontext/gomcp/jsonrpc"
 	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"github.com/llmcontext/gomcp/pkg/secrets"
 	"github.com/llmcontext/gomcp/types"
 )
 
-func (s *SdkServerDefinition) serverInitFunction(ctx context.Context, logger types.Logger) error {
+func (s *SdkToolProvider) serverInitFunction(ctx context.Context, logger types.Logger) error {
 	var result interface{}
 	var callErr, err error
 
@@ -24,14 +25,18 @@ func (s *SdkServerDefinition) serverInitFunction(ctx context.Context, logger typ
 	if callErr != nil {
 		return callErr
 	}
+	// the tool context may hold credentials, the fields
+	// tagged as secret are redacted
+	redacted := secrets.Redact(result)
 	logger.Info("tool provider initialized", types.LogArg{
-		"result": result,
+		"provider": s.name,
+		"result":   redacted,
 	})
 
 	// check if result as a property called logger of type types.Logger
 	if logger, ok := result.(types.Logger); ok {
 		logger.Info("tool provider initialized", types.LogArg{
-			"result": result,
+			"result": redacted,
 		})
 	}
 
@@ -51,34 +56,20 @@ func (t *SdkToolDefinition) toolProcessFunction(
 	toolArgs map[string]interface{},
 	output types.ToolCallResult,
 	logger types.Logger,
-	errChan chan *jsonrpc.JsonRpcError,
-) error {
+) *jsonrpc.JsonRpcError {
 
 	// let's check if the arguments match the schema
 	err := jsonschema.ValidateJsonSchemaWithObject(t.InputSchema, toolArgs)
 	if err != nil {
-		return err
+		return &jsonrpc.JsonRpcError{
+			Code:    jsonrpc.RpcInvalidParams,
+			Message: err.Error(),
+		}
 	}
 
 	// create a new context with the logger
 	goCtx := types.ContextWithLogger(ctx, logger)
 
-	go func() {
-		_, callErr, err := callFunction(t.toolHandlerFunction, goCtx, t.toolContext, toolArgs, output)
-		if err != nil {
-			errChan <- &jsonrpc.JsonRpcError{
-				Code:    jsonrpc.RpcInternalError,
-				Message: err.Error(),
-			}
-		} else if callErr != nil {
-			errChan <- &jsonrpc.JsonRpcError{
-				Code:    jsonrpc.RpcInternalError,
-				Message: callErr.Error(),
-			}
-		} else {
-			errChan <- nil
-		}
-	}()
-
-	return nil
+	_, callErr, err := callFunction(t.toolHandlerFunction, goCtx, t.toolContext, toolArgs, output)
+	return handlerError(callErr, err)
 }
@@ -6,38 +6,64 @@ import (
 	"reflect"
 
 	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"github.com/llmcontext/gomcp/pkg/secrets"
 	"github.com/llmcontext/gomcp/types"
 )
 
 // stitch everything together
 // so that we can use the server and tools
 func (s *SdkServerDefinition) Prepare() error {
-	// we setup the server
-	// check that the tools are valid
-	err := s.setupServer()
-	if err != nil {
-		return fmt.Errorf("failed to setup MCP server: %v", err)
+	toolNames := make(map[string]bool)
+	for _, provider := range s.toolProviders {
+		// we setup the provider
+		// check that the tools are valid
+		err := provider.setupProvider()
+		if err != nil {
+			return fmt.Errorf("failed to setup MCP server: %v", err)
+		}
+		provider.toolsInitializer.setBackoff(s.initMinBackoff, s.initMaxBackoff)
+
+		// we add all the tools to the tools registry
+		for _, tool := range provider.toolDefinitions {
+			if toolNames[tool.ToolName] {
+				return fmt.Errorf("tool %s is declared more than once", tool.ToolName)
+			}
+			toolNames[tool.ToolName] = true
+
+			err := tool.setupTool(provider)
+			if err != nil {
+				return fmt.Errorf("failed to setup tool %s: %v", tool.ToolName, err)
+			}
+		}
 	}
 
-	// we add all the tools to the tools registry
-	for _, tool := range s.toolDefinitions {
-		err := tool.setupTool(s)
+	for _, prompt := range s.promptDefinitions {
+		err := prompt.setupPrompt(s.toolProviders)
 		if err != nil {
-			return fmt.Errorf("failed to setup tool %s: %v", tool.ToolName, err)
+			return fmt.Errorf("failed to setup prompt %s: %v", prompt.PromptName, err)
 		}
 	}
 
 	return nil
 }
 
-// TODO: check that the configuration is valid eg. s.toolsInitFunction exists etc...
-func (s *SdkServerDefinition) setupServer() error {
+// ConfigurationType returns the type of the configuration expected
+// by the tools init function, or nil if it does not take any
+func (s *SdkToolProvider) ConfigurationType() reflect.Type {
+	fnType := reflect.TypeOf(s.toolsInitFunction)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnType.NumIn() != 2 {
+		return nil
+	}
+	return fnType.In(1)
+}
+
+func (s *SdkToolProvider) setupProvider() error {
 	// get the type of the configuration
 	configurationType := reflect.TypeOf(s.toolConfigurationData)
 
 	// Validate that toolHandler is a function
 	fnType := reflect.TypeOf(s.toolsInitFunction)
-	if fnType.Kind() != reflect.Func {
+	if fnType == nil || fnType.Kind() != reflect.Func {
 		return fmt.Errorf("toolInitFunction must be a function")
 	}
 
@@ -64,9 +90,20 @@ func (s *SdkServerDefinition) setupServer() error {
 		configType = fnType.In(1)
 
 		// check if the type is the same as the configuration type
+		if configurationType == nil {
+			return fmt.Errorf("toolInitFunction argument must be a pointer to a struct of type %s, but no configuration was provided", configType.String())
+		}
 		if configType != configurationType {
 			return fmt.Errorf("toolInitFunction argument must be a pointer to a struct of type %s, but got %s", configurationType.String(), configType.String())
 		}
+
+		// resolve the ${ENV_VAR} and ${file:/path} references, on a
+		// copy so that the configuration of the caller keeps them
+		interpolated, err := secrets.InterpolateCopy(s.toolConfigurationData)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate configuration: %v", err)
+		}
+		s.toolConfigurationData = interpolated
 	}
 
 	// the function must return a tool context, error
@@ -90,13 +127,14 @@ func (s *SdkServerDefinition) setupServer() error {
 	}
 	returnedContextType := fnType.Out(0).Elem()
 	returnedContextTypeName := returnedContextType.Name()
+	s.configurationType = configType
 	s.contextType = returnedContextType
 	s.contextTypeName = returnedContextTypeName
 
 	return nil
 }
 
-func (tool *SdkToolDefinition) setupTool(serverDefinition *SdkServerDefinition) error {
+func (tool *SdkToolDefinition) setupTool(provider *SdkToolProvider) error {
 	// Validate that toolHandler is a function
 	fnType := reflect.TypeOf(tool.toolHandlerFunction)
 	if fnType.Kind() != reflect.Func {
@@ -119,8 +157,8 @@ func (tool *SdkToolDefinition) setupTool(serverDefinition *SdkServerDefinition)
 	}
 
 	// the second argument must be a pointer to the tool context type
-	if fnType.In(1).Kind() != reflect.Ptr || fnType.In(1).Elem() != serverDefinition.contextType {
-		return fmt.Errorf("toolHandler for %s second argument must be a pointer to the context type: %s", tool.ToolName, serverDefinition.contextTypeName)
+	if fnType.In(1).Kind() != reflect.Ptr || fnType.In(1).Elem() != provider.contextType {
+		return fmt.Errorf("toolHandler for %s second argument must be a pointer to the context type: %s", tool.ToolName, provider.contextTypeName)
 	}
 
 	// the third argument must be a pointer to a struct
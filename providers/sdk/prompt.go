@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/llmcontext/gomcp/pkg/jsonschema"
+	"github.com/llmcontext/gomcp/pkg/prompts"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// SdkPromptDefinition is a prompt built by a Go function
+// instead of a template of the prompts file
+type SdkPromptDefinition struct {
+	PromptName            string
+	PromptDescription     string
+	promptHandlerFunction interface{}
+
+	// the provider whose tool context is given to the handler
+	provider *SdkToolProvider
+
+	// enhanced data
+	Arguments     []prompts.PromptArgumentDefinition
+	inputTypeName string
+}
+
+// AddPrompt declares a prompt built by promptHandler, a function with the signature:
+//
+//	func(ctx context.Context, toolCtx *MyToolContext, input *MyPromptInput, output types.PromptGetResult) error
+//
+// the tool context is the one of the provider whose init function returns
+// a *MyToolContext, the arguments of the prompt are the fields of MyPromptInput
+func (s *SdkServerDefinition) AddPrompt(promptName string, description string, promptHandler interface{}) error {
+	for _, prompt := range s.promptDefinitions {
+		if prompt.PromptName == promptName {
+			return fmt.Errorf("prompt %s is declared more than once", promptName)
+		}
+	}
+	// the prompts files loaded later are checked by the registry
+	if s.promptsRegistry.HasPrompt(promptName) {
+		return fmt.Errorf("prompt %s is declared both in a prompts file and with AddPrompt", promptName)
+	}
+	s.promptDefinitions = append(s.promptDefinitions, &SdkPromptDefinition{
+		PromptName:            promptName,
+		PromptDescription:     description,
+		promptHandlerFunction: promptHandler,
+	})
+	return nil
+}
+
+func (s *SdkServerDefinition) GetPromptDefinition(promptName string) *SdkPromptDefinition {
+	for _, prompt := range s.promptDefinitions {
+		if prompt.PromptName == promptName {
+			return prompt
+		}
+	}
+	return nil
+}
+
+func (prompt *SdkPromptDefinition) setupPrompt(providers []*SdkToolProvider) error {
+	// Validate that promptHandler is a function
+	fnType := reflect.TypeOf(prompt.promptHandlerFunction)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("promptHandler must be a function")
+	}
+
+	// the function must have 4 arguments:
+	// the golang context
+	// the tool context
+	// the input
+	// the output
+	if fnType.NumIn() != 4 {
+		return fmt.Errorf("promptHandler for %s must have 4 arguments", prompt.PromptName)
+	}
+
+	// the first argument must be a golang context
+	goContextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	if fnType.In(0) != goContextType {
+		return fmt.Errorf("promptHandler for %s first argument must be a golang context", prompt.PromptName)
+	}
+
+	// the second argument must be a pointer to the context type of one of the providers
+	if fnType.In(1).Kind() != reflect.Ptr {
+		return fmt.Errorf("promptHandler for %s second argument must be a pointer to a tool context", prompt.PromptName)
+	}
+	for _, provider := range providers {
+		if fnType.In(1).Elem() == provider.contextType {
+			prompt.provider = provider
+			break
+		}
+	}
+	if prompt.provider == nil {
+		return fmt.Errorf("promptHandler for %s second argument: no tool provider has the context type %s", prompt.PromptName, fnType.In(1).Elem().String())
+	}
+
+	// the third argument must be a pointer to a struct
+	if fnType.In(2).Kind() != reflect.Ptr || fnType.In(2).Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("promptHandler for %s third argument must be a pointer to a struct", prompt.PromptName)
+	}
+	inputSchema, inputTypeName, err := jsonschema.GetSchemaFromType(fnType.In(2))
+	if err != nil {
+		return fmt.Errorf("error generating schema for promptHandler for %s third argument", prompt.PromptName)
+	}
+
+	// the arguments of a prompt are strings
+	required := make(map[string]bool)
+	for _, name := range inputSchema.Required {
+		required[name] = true
+	}
+	arguments := []prompts.PromptArgumentDefinition{}
+	for property := inputSchema.Properties.Oldest(); property != nil; property = property.Next() {
+		if property.Value.Type != "string" {
+			return fmt.Errorf("promptHandler for %s third argument: field %s must be a string", prompt.PromptName, property.Key)
+		}
+		// the values of an enum are suggested when the argument is completed
+		values := []string{}
+		for _, value := range property.Value.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		arguments = append(arguments, prompts.PromptArgumentDefinition{
+			Name:        property.Key,
+			Description: property.Value.Description,
+			Required:    required[property.Key],
+			Values:      values,
+		})
+	}
+
+	// the fourth argument must be an implementation of types.PromptGetResult
+	promptGetResultType := reflect.TypeOf((*types.PromptGetResult)(nil)).Elem()
+	if fnType.In(3) != promptGetResultType {
+		return fmt.Errorf("promptHandler for %s fourth argument must be types.PromptGetResult but is %s", prompt.PromptName, fnType.In(3).String())
+	}
+
+	// the function must return an error
+	if fnType.NumOut() != 1 || fnType.Out(0).String() != "error" {
+		return fmt.Errorf("promptHandler for %s must return an error", prompt.PromptName)
+	}
+
+	prompt.Arguments = arguments
+	prompt.inputTypeName = inputTypeName
+
+	return nil
+}
+
+// promptDefinition describes the prompt like the ones of the prompts file
+func (prompt *SdkPromptDefinition) promptDefinition() *prompts.PromptDefinition {
+	return &prompts.PromptDefinition{
+		Name:        prompt.PromptName,
+		Description: prompt.PromptDescription,
+		Arguments:   prompt.Arguments,
+	}
+}
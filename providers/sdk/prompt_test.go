@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/llmcontext/gomcp/protocol/mcp"
+	"github.com/llmcontext/gomcp/providers/results"
+	"github.com/llmcontext/gomcp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type trackerContext struct {
+	tickets []string
+}
+
+type sprintPromptInput struct {
+	Sprint string `json:"sprint" jsonschema_description:"the name of the sprint"`
+	Focus  string `json:"focus,omitempty" jsonschema_description:"what to focus on"`
+}
+
+func trackerInit(ctx context.Context) (*trackerContext, error) {
+	return &trackerContext{tickets: []string{"GO-1", "GO-2"}}, nil
+}
+
+func sprintReview(ctx context.Context, toolCtx *trackerContext, input *sprintPromptInput, output types.PromptGetResult) error {
+	output.AddTextContent(types.RoleUser, fmt.Sprintf("Review %s (%s): %v", input.Sprint, input.Focus, toolCtx.tickets))
+	return nil
+}
+
+func TestAddPrompt(t *testing.T) {
+	definition := NewMcpSdkServerDefinition("test", "0.1.0")
+	definition.WithTools(nil, trackerInit)
+	assert.NoError(t, definition.AddPrompt("sprint_review", "Reviews a sprint", sprintReview))
+	assert.Error(t, definition.AddPrompt("sprint_review", "Reviews a sprint", sprintReview))
+	if !assert.NoError(t, definition.Prepare()) {
+		return
+	}
+
+	list := definition.GetListOfPrompts()
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "sprint_review", list[0].Name)
+		assert.Equal(t, "sprint", list[0].Arguments[0].Name)
+		assert.Equal(t, "the name of the sprint", list[0].Arguments[0].Description)
+		assert.True(t, list[0].Arguments[0].Required)
+		assert.False(t, list[0].Arguments[1].Required)
+	}
+
+	params := &mcp.JsonRpcRequestPromptsGetParams{
+		Name:      "sprint_review",
+		Arguments: map[string]string{"sprint": "S42", "focus": "bugs"},
+	}
+	result, rpcErr := definition.ExecutePromptGet(context.Background(), params, logger.NewTeeLogger(), logger.NewTeeLogger())
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "Review S42 (bugs): [GO-1 GO-2]",
+		result.(*results.PromptGetResultImpl).Messages[0].(map[string]interface{})["content"].(map[string]interface{})["text"])
+
+	params.Arguments = map[string]string{}
+	_, rpcErr = definition.ExecutePromptGet(context.Background(), params, logger.NewTeeLogger(), logger.NewTeeLogger())
+	if assert.NotNil(t, rpcErr) {
+		assert.Contains(t, rpcErr.Message, "missing argument: sprint")
+	}
+}
+
+func TestAddPromptWithoutProvider(t *testing.T) {
+	definition := NewMcpSdkServerDefinition("test", "0.1.0")
+	assert.NoError(t, definition.AddPrompt("sprint_review", "Reviews a sprint", sprintReview))
+	assert.ErrorContains(t, definition.Prepare(), "no tool provider has the context type")
+}
+
+func TestAddPromptConflicts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"prompts.yaml": &fstest.MapFile{Data: []byte(`prompts:
+  - name: sprint_review
+    description: Reviews a sprint
+    prompt: Review the sprint
+`)},
+	}
+
+	// the prompts file is loaded first
+	definition := NewMcpSdkServerDefinition("test", "0.1.0")
+	_, err := definition.AddTemplateFS(fsys, "*.yaml")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, definition.AddPrompt("sprint_review", "Reviews a sprint", sprintReview), "declared both")
+
+	// the prompt handler is declared first
+	definition = NewMcpSdkServerDefinition("test", "0.1.0")
+	assert.NoError(t, definition.AddPrompt("sprint_review", "Reviews a sprint", sprintReview))
+	_, err = definition.AddTemplateFS(fsys, "*.yaml")
+	assert.ErrorContains(t, err, "declared both")
+	assert.Len(t, definition.GetListOfPrompts(), 1)
+}
@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"reflect"
+
+	"github.com/llmcontext/gomcp/types"
+)
+
+// SdkToolProvider is a set of tools sharing the same tool context,
+// the context is created by the tools init function
+type SdkToolProvider struct {
+	name                  string
+	toolConfigurationData interface{}
+	toolsInitFunction     interface{}
+	toolDefinitions       []*SdkToolDefinition
+
+	// enhanced data
+	configurationType reflect.Type
+	contextType       reflect.Type
+	contextTypeName   string
+	// the tool context retrieve from the tool init function
+	toolContext interface{}
+	// makes sure the tool context is only initialized once
+	toolsInitializer *toolContextInitializer
+}
+
+func NewSdkToolProvider(name string, toolsInitFunction interface{}) *SdkToolProvider {
+	return &SdkToolProvider{
+		name:              name,
+		toolsInitFunction: toolsInitFunction,
+		toolDefinitions:   []*SdkToolDefinition{},
+		toolsInitializer:  newToolContextInitializer(),
+	}
+}
+
+func NewToolProvider(name string, toolsInitFunction interface{}) types.ToolProvider {
+	return NewSdkToolProvider(name, toolsInitFunction)
+}
+
+func (p *SdkToolProvider) ProviderName() string {
+	return p.name
+}
+
+func (p *SdkToolProvider) AddTool(toolName string, description string, toolHandler interface{}) error {
+	p.toolDefinitions = append(p.toolDefinitions, &SdkToolDefinition{
+		ToolName:            toolName,
+		ToolDescription:     description,
+		toolHandlerFunction: toolHandler,
+		provider:            p,
+	})
+	return nil
+}
+
+func (p *SdkToolProvider) GetListOfTools() []*SdkToolDefinition {
+	return p.toolDefinitions
+}
@@ -0,0 +1,183 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/llmcontext/gomcp/types"
+)
+
+const (
+	// the largest message accepted from the server
+	commandMaxMessageSize = 16 * 1024 * 1024
+	// the delay given to the server to exit once its stdin is closed
+	commandExitTimeout = 5 * time.Second
+)
+
+// CommandTransport starts an MCP server as a child process and
+// exchanges the messages through its standard input and output,
+// this is the client side of the StdioTransport
+type CommandTransport struct {
+	command *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  io.ReadCloser
+
+	sendMutex sync.Mutex
+	closeOnce sync.Once
+	exited    chan struct{}
+	logger    types.Logger
+	onStarted func()
+	onMessage func(json.RawMessage)
+	onClose   func()
+	onError   func(error)
+}
+
+// NewCommandTransport prepares the command, the process is started by Start.
+// The standard error of the server is forwarded to our standard error.
+func NewCommandTransport(name string, args []string, logger types.Logger) *CommandTransport {
+	command := exec.Command(name, args...)
+	command.Stderr = os.Stderr
+	return &CommandTransport{
+		command: command,
+		exited:  make(chan struct{}),
+		logger:  logger,
+	}
+}
+
+// SetStderr changes where the standard error of the server is written,
+// it must be called before Start
+func (t *CommandTransport) SetStderr(stderr io.Writer) {
+	t.command.Stderr = stderr
+}
+
+func (t *CommandTransport) Start(ctx context.Context) error {
+	var err error
+	t.stdin, err = t.command.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	t.stdout, err = t.command.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	if err := t.command.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", t.command.Path, err)
+	}
+	t.logger.Info("server process started", types.LogArg{
+		"command": t.command.String(),
+		"pid":     t.command.Process.Pid,
+	})
+
+	if t.onStarted != nil {
+		t.onStarted()
+	}
+
+	errChan := make(chan error, 1)
+	go t.readLoop(errChan)
+
+	select {
+	case err := <-errChan:
+		t.Close()
+		return err
+	case <-ctx.Done():
+		t.Close()
+		return ctx.Err()
+	}
+}
+
+func (t *CommandTransport) readLoop(errChan chan error) {
+	scanner := bufio.NewScanner(t.stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), commandMaxMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if t.onMessage != nil {
+			// the scanner reuses its buffer
+			message := make(json.RawMessage, len(line))
+			copy(message, line)
+			t.onMessage(message)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if t.onError != nil {
+			t.onError(err)
+		}
+		// the stream can't be resynchronized, eg. a message was too large
+		t.command.Process.Kill()
+		t.command.Wait()
+		close(t.exited)
+		errChan <- fmt.Errorf("error reading from server: %w", err)
+		return
+	}
+
+	// the server closed its stdout, most likely because it exited
+	err := t.command.Wait()
+	close(t.exited)
+	if err != nil {
+		errChan <- fmt.Errorf("server process exited: %w", err)
+		return
+	}
+	errChan <- fmt.Errorf("server process exited")
+}
+
+func (t *CommandTransport) Send(message json.RawMessage) error {
+	t.sendMutex.Lock()
+	defer t.sendMutex.Unlock()
+	if t.stdin == nil {
+		return fmt.Errorf("server process not started")
+	}
+	_, err := fmt.Fprintf(t.stdin, "%s\n", message)
+	return err
+}
+
+func (t *CommandTransport) OnStarted(callback func()) {
+	t.onStarted = callback
+}
+
+func (t *CommandTransport) OnMessage(callback func(json.RawMessage)) {
+	t.onMessage = callback
+}
+
+func (t *CommandTransport) OnClose(callback func()) {
+	t.onClose = callback
+}
+
+func (t *CommandTransport) OnError(callback func(error)) {
+	t.onError = callback
+}
+
+// Close closes the standard input of the server, which is the normal way
+// to stop a stdio server, and kills it if it does not exit in time
+func (t *CommandTransport) Close() {
+	t.closeOnce.Do(func() {
+		t.sendMutex.Lock()
+		if t.stdin != nil {
+			t.stdin.Close()
+		}
+		t.sendMutex.Unlock()
+
+		if t.command.Process != nil {
+			select {
+			case <-t.exited:
+			case <-time.After(commandExitTimeout):
+				t.logger.Error("server process did not exit, killing it", types.LogArg{
+					"pid": t.command.Process.Pid,
+				})
+				t.command.Process.Kill()
+			}
+		}
+
+		if t.onClose != nil {
+			t.onClose()
+		}
+	})
+}
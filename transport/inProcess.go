@@ -0,0 +1,125 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const inProcessBufferSize = 1024
+
+// InProcessTransport connects a server to Go code running in the same
+// process, eg. a test: Deliver sends a message to the server and
+// the messages sent by the server are read from Outgoing
+type InProcessTransport struct {
+	mu        sync.Mutex
+	onStarted func()
+	onMessage func(json.RawMessage)
+	onClose   func()
+	onError   func(error)
+
+	incoming  chan json.RawMessage
+	outgoing  chan json.RawMessage
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewInProcessTransport() *InProcessTransport {
+	return &InProcessTransport{
+		incoming: make(chan json.RawMessage, inProcessBufferSize),
+		outgoing: make(chan json.RawMessage, inProcessBufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start delivers the incoming messages until the transport is closed
+func (t *InProcessTransport) Start(ctx context.Context) error {
+	t.mu.Lock()
+	onStarted := t.onStarted
+	t.mu.Unlock()
+	if onStarted != nil {
+		onStarted()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Close()
+			return ctx.Err()
+		case <-t.done:
+			return fmt.Errorf("in-process transport closed")
+		case message := <-t.incoming:
+			t.mu.Lock()
+			onMessage := t.onMessage
+			t.mu.Unlock()
+			if onMessage != nil {
+				onMessage(message)
+			}
+		}
+	}
+}
+
+func (t *InProcessTransport) Send(message json.RawMessage) error {
+	// the caller may reuse the buffer
+	copied := make(json.RawMessage, len(message))
+	copy(copied, message)
+
+	select {
+	case <-t.done:
+		return fmt.Errorf("in-process transport closed")
+	case t.outgoing <- copied:
+		return nil
+	}
+}
+
+// Deliver sends a message to the other side of the transport
+func (t *InProcessTransport) Deliver(message json.RawMessage) error {
+	select {
+	case <-t.done:
+		return fmt.Errorf("in-process transport closed")
+	case t.incoming <- message:
+		return nil
+	}
+}
+
+// Outgoing returns the messages sent through the transport
+func (t *InProcessTransport) Outgoing() <-chan json.RawMessage {
+	return t.outgoing
+}
+
+func (t *InProcessTransport) OnStarted(callback func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onStarted = callback
+}
+
+func (t *InProcessTransport) OnMessage(callback func(json.RawMessage)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onMessage = callback
+}
+
+func (t *InProcessTransport) OnClose(callback func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onClose = callback
+}
+
+func (t *InProcessTransport) OnError(callback func(error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onError = callback
+}
+
+func (t *InProcessTransport) Close() {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.mu.Lock()
+		onClose := t.onClose
+		t.mu.Unlock()
+		if onClose != nil {
+			onClose()
+		}
+	})
+}
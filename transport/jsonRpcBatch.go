@@ -0,0 +1,157 @@
+package transport
+
+import (
+	"encoding/json"
+
+	"github.com/llmcontext/gomcp/jsonrpc"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// jsonRpcBatch collects the responses to the requests of a batch, they
+// are sent together, in the order of the requests, once all are answered
+type jsonRpcBatch struct {
+	responses []json.RawMessage
+	// the number of responses not sent yet by the handlers
+	waiting int
+}
+
+// batchSlot is the place of the response to a request in its batch, the
+// slots are indexed by the id of the request: the handlers answer with
+// the same *JsonRpcRequestId, so that two batches, or a batch and a single
+// request, can use the same id
+type batchSlot struct {
+	batch *jsonRpcBatch
+	index int
+}
+
+// batchMessage is a message of a batch, with the request parsed
+// if the message is a request
+type batchMessage struct {
+	nature     jsonrpc.MessageNature
+	rawMessage jsonrpc.JsonRpcRawMessage
+	request    *jsonrpc.JsonRpcRequest
+}
+
+// handleBatch dispatches the messages of a batch, the notifications and
+// the responses have no response, like when they are sent alone
+func (t *JsonRpcTransport) handleBatch(message json.RawMessage, onMessage func(message JsonRpcMessage, jsonRpcTransport *JsonRpcTransport)) {
+	elements, err := jsonrpc.SplitJsonRpcBatch(message)
+	if err != nil {
+		// an empty batch gets a single error, not an array
+		t.SendError(jsonrpc.RpcInvalidRequest, err.Error(), nil)
+		return
+	}
+
+	batch := &jsonRpcBatch{}
+	slots := map[*jsonrpc.JsonRpcRequestId]*batchSlot{}
+	// the ids of the requests of the batch must be unique
+	ids := map[string]bool{}
+	messages := make([]batchMessage, 0, len(elements))
+	for _, element := range elements {
+		nature, rawMessage, err := jsonrpc.CheckJsonMessage(element)
+		if err != nil || nature == jsonrpc.MessageNatureBatchRequest {
+			batch.responses = append(batch.responses, t.marshalError(jsonrpc.RpcInvalidRequest, "invalid message in batch", nil))
+			continue
+		}
+		if nature != jsonrpc.MessageNatureRequest {
+			messages = append(messages, batchMessage{nature: nature, rawMessage: rawMessage})
+			continue
+		}
+
+		request, requestId, rpcErr := jsonrpc.ParseJsonRpcRequest(rawMessage)
+		if rpcErr != nil {
+			batch.responses = append(batch.responses, t.marshalError(rpcErr.Code, rpcErr.Message, requestId))
+			continue
+		}
+		key := jsonrpc.RequestIdToString(request.Id)
+		if ids[key] {
+			batch.responses = append(batch.responses, t.marshalError(jsonrpc.RpcInvalidRequest, "duplicate request id in batch", request.Id))
+			continue
+		}
+		ids[key] = true
+		slots[request.Id] = &batchSlot{batch: batch, index: len(batch.responses)}
+		batch.responses = append(batch.responses, nil)
+		batch.waiting++
+		messages = append(messages, batchMessage{nature: nature, request: request})
+	}
+
+	// the slots are registered before the dispatch: the
+	// handlers may answer before the end of the batch
+	t.mu.Lock()
+	for key, slot := range slots {
+		t.batches[key] = slot
+	}
+	t.mu.Unlock()
+
+	for _, message := range messages {
+		if message.request != nil {
+			onMessage(JsonRpcMessage{
+				Request: message.request,
+				Method:  message.request.Method,
+			}, t)
+		} else {
+			t.dispatch(message.nature, message.rawMessage, onMessage)
+		}
+	}
+
+	// only invalid messages, notifications and responses, otherwise
+	// the batch is sent with the last response of the handlers
+	if len(slots) == 0 && len(batch.responses) > 0 {
+		t.sendBatch(batch)
+	}
+}
+
+// collectBatchResponse stores the response if it answers a request of a
+// batch and sends the batch once complete, it returns false if the
+// request was not part of a batch
+func (t *JsonRpcTransport) collectBatchResponse(id *jsonrpc.JsonRpcRequestId, response json.RawMessage) bool {
+	if id == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	slot, found := t.batches[id]
+	if !found {
+		t.mu.Unlock()
+		return false
+	}
+	delete(t.batches, id)
+	slot.batch.responses[slot.index] = response
+	slot.batch.waiting--
+	complete := slot.batch.waiting == 0
+	t.mu.Unlock()
+
+	if complete {
+		t.sendBatch(slot.batch)
+	}
+	return true
+}
+
+func (t *JsonRpcTransport) sendBatch(batch *jsonRpcBatch) {
+	jsonMessage, err := json.Marshal(batch.responses)
+	if err != nil {
+		t.logger.Error("error marshalling batch", types.LogArg{
+			"error": err,
+			"name":  t.name,
+		})
+		return
+	}
+	if err := t.transport.Send(jsonMessage); err != nil {
+		t.logger.Error("error sending batch", types.LogArg{
+			"error": err,
+			"name":  t.name,
+		})
+	}
+}
+
+// marshalError returns an error response, it can't fail
+func (t *JsonRpcTransport) marshalError(code int, message string, id *jsonrpc.JsonRpcRequestId) json.RawMessage {
+	jsonMessage, _ := jsonrpc.MarshalJsonRpcResponse(&jsonrpc.JsonRpcResponse{
+		Error: &jsonrpc.JsonRpcError{
+			Code:    code,
+			Message: message,
+		},
+		Id: id,
+	})
+	return jsonMessage
+}
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// startEchoTransport answers the requests with their params, the
+// "slow" requests are answered after the others
+func startEchoTransport(t *testing.T) (*JsonRpcTransport, *InProcessTransport, chan string) {
+	inProcess := NewInProcessTransport()
+	jsonRpcTransport := NewJsonRpcTransport(inProcess, "test", logger.NewTeeLogger())
+	notifications := make(chan string, 10)
+	started := make(chan struct{})
+	jsonRpcTransport.OnStarted(func() {
+		close(started)
+	})
+	go jsonRpcTransport.Start(context.Background(), func(message JsonRpcMessage, jsonRpcTransport *JsonRpcTransport) {
+		request := message.Request
+		switch {
+		case request.Id == nil:
+			notifications <- request.Method
+		case request.Method == "slow":
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				jsonRpcTransport.SendJsonRpcResponse(request.Params.NamedParams, request.Id)
+			}()
+		case request.Method == "fail":
+			jsonRpcTransport.SendError(-32000, "failed", request.Id)
+		default:
+			jsonRpcTransport.SendJsonRpcResponse(request.Params.NamedParams, request.Id)
+		}
+	})
+	<-started
+	return jsonRpcTransport, inProcess, notifications
+}
+
+func receive(t *testing.T, inProcess *InProcessTransport) string {
+	select {
+	case message := <-inProcess.Outgoing():
+		return string(message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message sent")
+		return ""
+	}
+}
+
+func TestBatch(t *testing.T) {
+	jsonRpcTransport, inProcess, notifications := startEchoTransport(t)
+	defer jsonRpcTransport.Close()
+
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`[
+		{"jsonrpc":"2.0","id":1,"method":"slow","params":{"n":1}},
+		{"jsonrpc":"2.0","method":"notify"},
+		{"jsonrpc":"2.0","id":"b","method":"echo","params":{"n":2}},
+		{"jsonrpc":"2.0","id":3,"method":"fail"},
+		1
+	]`)))
+
+	// the responses are in the order of the requests, the notification has none
+	assert.JSONEq(t, `[
+		{"jsonrpc":"2.0","id":1,"result":{"n":1}},
+		{"jsonrpc":"2.0","id":"b","result":{"n":2}},
+		{"jsonrpc":"2.0","id":3,"error":{"code":-32000,"message":"failed"}},
+		{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid message in batch"}}
+	]`, receive(t, inProcess))
+	assert.Equal(t, "notify", <-notifications)
+	assert.Empty(t, jsonRpcTransport.batches)
+
+	// the requests sent alone are not affected
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":4,"method":"echo","params":{"n":4}}`)))
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":4,"result":{"n":4}}`, receive(t, inProcess))
+}
+
+func TestBatchOfNotifications(t *testing.T) {
+	jsonRpcTransport, inProcess, notifications := startEchoTransport(t)
+	defer jsonRpcTransport.Close()
+
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`[{"jsonrpc":"2.0","method":"first"},{"jsonrpc":"2.0","method":"second"}]`)))
+	assert.Equal(t, "first", <-notifications)
+	assert.Equal(t, "second", <-notifications)
+
+	// nothing is sent for the batch: the next message is the response to a single request
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"echo","params":{}}`)))
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, receive(t, inProcess))
+}
+
+func TestEmptyBatch(t *testing.T) {
+	jsonRpcTransport, inProcess, _ := startEchoTransport(t)
+	defer jsonRpcTransport.Close()
+
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`[]`)))
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`, receive(t, inProcess))
+}
+
+func TestBatchesReusingIds(t *testing.T) {
+	jsonRpcTransport, inProcess, _ := startEchoTransport(t)
+	defer jsonRpcTransport.Close()
+
+	// a single request and two batches in flight with the same id
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"slow","params":{"from":"single"}}`)))
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`[{"jsonrpc":"2.0","id":1,"method":"slow","params":{"from":"first"}},{"jsonrpc":"2.0","id":2,"method":"echo","params":{"from":"first"}}]`)))
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(`[{"jsonrpc":"2.0","id":1,"method":"echo","params":{"from":"second"}}]`)))
+
+	// the fast batch is answered first
+	assert.JSONEq(t, `[{"jsonrpc":"2.0","id":1,"result":{"from":"second"}}]`, receive(t, inProcess))
+
+	received := []string{receive(t, inProcess), receive(t, inProcess)}
+	assert.ElementsMatch(t, []string{
+		`{"jsonrpc":"2.0","result":{"from":"single"},"id":1}`,
+		`[{"jsonrpc":"2.0","result":{"from":"first"},"id":1},{"jsonrpc":"2.0","result":{"from":"first"},"id":2}]`,
+	}, received)
+	assert.Empty(t, jsonRpcTransport.batches)
+}
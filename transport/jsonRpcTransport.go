@@ -3,25 +3,42 @@ package transport
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/llmcontext/gomcp/jsonrpc"
 	"github.com/llmcontext/gomcp/types"
 )
 
+// ErrTransportClosed is returned by Call when the transport stops
+// before the response is received
+var ErrTransportClosed = errors.New("transport closed")
+
 type pendingRequest struct {
 	method    string
 	requestId *jsonrpc.JsonRpcRequestId
+	// set for the requests sent by Call, the response is
+	// sent to that channel instead of onMessage
+	response chan *jsonrpc.JsonRpcResponse
 }
 
 type JsonRpcTransport struct {
-	transport     types.Transport
-	logger        types.Logger
+	transport types.Transport
+	logger    types.Logger
+	onStarted func()
+	// the requests can be sent by the handlers while the
+	// responses are read, mu protects the fields below
+	mu            sync.Mutex
 	lastRequestId int
-	onStarted     func()
 	// pendingRequests is a map of message id to pending request
 	pendingRequests map[string]*pendingRequest
-	name            string
+	// the requests of the batches waiting for a response
+	batches map[*jsonrpc.JsonRpcRequestId]*batchSlot
+	name    string
+	// closed when the transport stops
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
 type JsonRpcMessage struct {
@@ -64,7 +81,9 @@ func NewJsonRpcTransport(transport types.Transport, name string, logger types.Lo
 		logger:          logger,
 		lastRequestId:   0,
 		pendingRequests: make(map[string]*pendingRequest),
+		batches:         make(map[*jsonrpc.JsonRpcRequestId]*batchSlot),
 		name:            name,
+		closed:          make(chan struct{}),
 	}
 }
 
@@ -87,89 +106,7 @@ func (t *JsonRpcTransport) Start(ctx context.Context, onMessage func(message Jso
 				"name":    t.name,
 				"message": string(message),
 			})
-			// check the message nature
-			nature, jsonRpcRawMessage, err := jsonrpc.CheckJsonMessage(message)
-			if err != nil {
-				t.logger.Error("error checking message", types.LogArg{
-					"message": string(message),
-					"error":   err,
-					"name":    t.name,
-				})
-				return
-			}
-
-			// the MCP protocol does not support batch requests
-			switch nature {
-			case jsonrpc.MessageNatureBatchRequest:
-				t.logger.Error("batch requests not supported in protocol", types.LogArg{})
-				return
-			case jsonrpc.MessageNatureRequest:
-				request, _, rpcErr := jsonrpc.ParseJsonRpcRequest(jsonRpcRawMessage)
-				if rpcErr != nil {
-					t.logger.Error("error parsing request", types.LogArg{
-						"error": rpcErr,
-						"name":  t.name,
-					})
-					return
-				}
-				onMessage(JsonRpcMessage{
-					Request:  request,
-					Method:   request.Method,
-					Response: nil,
-				}, t)
-
-			case jsonrpc.MessageNatureResponse:
-				response, _, rpcErr := jsonrpc.ParseJsonRpcResponse(jsonRpcRawMessage)
-
-				if rpcErr != nil {
-					t.logger.Error("error parsing response", types.LogArg{
-						"error": rpcErr,
-						"name":  t.name,
-					})
-					return
-				}
-				pendingRequestMethod, reqId := t.GetPendingRequest(response.Id)
-				if pendingRequestMethod == "" {
-					t.logger.Error("pending request method not found", types.LogArg{
-						"requestId": jsonrpc.RequestIdToString(response.Id),
-						"name":      t.name,
-						"response":  response,
-					})
-					return
-				}
-				t.logger.Info("pending request method found", types.LogArg{
-					"method": pendingRequestMethod,
-					"name":   t.name,
-					"id":     jsonrpc.RequestIdToString(reqId),
-				})
-				onMessage(JsonRpcMessage{
-					Response: response,
-					Method:   pendingRequestMethod,
-				}, t)
-			case jsonrpc.MessageNatureNotification:
-				t.logger.Info("notification received", types.LogArg{
-					"name": t.name,
-				})
-				request, _, rpcErr := jsonrpc.ParseJsonRpcRequest(jsonRpcRawMessage)
-				if rpcErr != nil {
-					t.logger.Error("error parsing notification", types.LogArg{
-						"error": rpcErr,
-						"name":  t.name,
-					})
-					return
-				}
-				onMessage(JsonRpcMessage{
-					Request:  request,
-					Method:   request.Method,
-					Response: nil,
-				}, t)
-			default:
-				t.logger.Error("invalid message nature", types.LogArg{
-					"nature": nature,
-					"name":   t.name,
-				})
-				return
-			}
+			t.handleMessage(message, onMessage)
 		}
 	})
 
@@ -204,6 +141,9 @@ func (t *JsonRpcTransport) Start(ctx context.Context, onMessage func(message Jso
 		errChan <- err
 	}()
 
+	// the requests waiting for a response will never get it
+	defer t.markClosed()
+
 	select {
 	case err := <-errChan:
 		return err
@@ -212,6 +152,130 @@ func (t *JsonRpcTransport) Start(ctx context.Context, onMessage func(message Jso
 	}
 }
 
+// handleMessage checks the nature of the message and dispatches it
+func (t *JsonRpcTransport) handleMessage(message json.RawMessage, onMessage func(message JsonRpcMessage, jsonRpcTransport *JsonRpcTransport)) {
+	nature, jsonRpcRawMessage, err := jsonrpc.CheckJsonMessage(message)
+	if err != nil {
+		t.logger.Error("error checking message", types.LogArg{
+			"message": string(message),
+			"error":   err,
+			"name":    t.name,
+		})
+		return
+	}
+	if nature == jsonrpc.MessageNatureBatchRequest {
+		t.handleBatch(message, onMessage)
+		return
+	}
+	t.dispatch(nature, jsonRpcRawMessage, onMessage)
+}
+
+// dispatch parses a single message and passes it to onMessage
+func (t *JsonRpcTransport) dispatch(nature jsonrpc.MessageNature, jsonRpcRawMessage jsonrpc.JsonRpcRawMessage, onMessage func(message JsonRpcMessage, jsonRpcTransport *JsonRpcTransport)) {
+	switch nature {
+	case jsonrpc.MessageNatureRequest:
+		request, _, rpcErr := jsonrpc.ParseJsonRpcRequest(jsonRpcRawMessage)
+		if rpcErr != nil {
+			t.logger.Error("error parsing request", types.LogArg{
+				"error": rpcErr,
+				"name":  t.name,
+			})
+			return
+		}
+		onMessage(JsonRpcMessage{
+			Request:  request,
+			Method:   request.Method,
+			Response: nil,
+		}, t)
+
+	case jsonrpc.MessageNatureResponse:
+		response, _, rpcErr := jsonrpc.ParseJsonRpcResponse(jsonRpcRawMessage)
+
+		if rpcErr != nil {
+			t.logger.Error("error parsing response", types.LogArg{
+				"error": rpcErr,
+				"name":  t.name,
+			})
+			return
+		}
+		pending := t.takePendingRequest(response.Id)
+		if pending == nil {
+			t.logger.Error("pending request method not found", types.LogArg{
+				"requestId": jsonrpc.RequestIdToString(response.Id),
+				"name":      t.name,
+				"response":  response,
+			})
+			return
+		}
+		t.logger.Info("pending request method found", types.LogArg{
+			"method": pending.method,
+			"name":   t.name,
+			"id":     jsonrpc.RequestIdToString(pending.requestId),
+		})
+		// Call is waiting for that response
+		if pending.response != nil {
+			pending.response <- response
+			return
+		}
+		onMessage(JsonRpcMessage{
+			Response: response,
+			Method:   pending.method,
+		}, t)
+	case jsonrpc.MessageNatureNotification:
+		t.logger.Info("notification received", types.LogArg{
+			"name": t.name,
+		})
+		request, _, rpcErr := jsonrpc.ParseJsonRpcRequest(jsonRpcRawMessage)
+		if rpcErr != nil {
+			t.logger.Error("error parsing notification", types.LogArg{
+				"error": rpcErr,
+				"name":  t.name,
+			})
+			return
+		}
+		onMessage(JsonRpcMessage{
+			Request:  request,
+			Method:   request.Method,
+			Response: nil,
+		}, t)
+	default:
+		t.logger.Error("invalid message nature", types.LogArg{
+			"nature": nature,
+			"name":   t.name,
+		})
+		return
+	}
+}
+
+// Call sends a request and waits for its response, until the context is
+// done or the transport stops. A response with an error is returned as is,
+// err is only set if no response is received. Call can be used concurrently,
+// but not from the onMessage callback: the response would never be read.
+func (t *JsonRpcTransport) Call(ctx context.Context, method string, params interface{}) (*jsonrpc.JsonRpcResponse, error) {
+	request := buildJsonRpcRequestWithNamedParams(method, params, t.GetNextRequestId())
+	if request == nil {
+		return nil, fmt.Errorf("failed to create %s request", method)
+	}
+
+	responseChan := make(chan *jsonrpc.JsonRpcResponse, 1)
+	if err := t.sendRequest(request, responseChan); err != nil {
+		t.takePendingRequest(request.Id)
+		return nil, fmt.Errorf("failed to send %s request: %v", method, err)
+	}
+
+	select {
+	case response := <-responseChan:
+		return response, nil
+	case <-ctx.Done():
+		// a late response is logged as unknown
+		t.takePendingRequest(request.Id)
+		return nil, fmt.Errorf("%s: %w", method, ctx.Err())
+	case <-t.closed:
+		t.takePendingRequest(request.Id)
+		return nil, fmt.Errorf("%s: %w", method, ErrTransportClosed)
+	}
+}
+
 func (t *JsonRpcTransport) SendRequestWithMethodAndParams(method string, params interface{}) (*jsonrpc.JsonRpcRequestId, error) {
 	requestId := t.GetNextRequestId()
 	request := buildJsonRpcRequestWithNamedParams(
@@ -235,6 +299,12 @@ func (t *JsonRpcTransport) SendResponseWithResults(reqId *jsonrpc.JsonRpcRequest
 }
 
 func (t *JsonRpcTransport) SendRequest(request *jsonrpc.JsonRpcRequest) error {
+	return t.sendRequest(request, nil)
+}
+
+// sendRequest sends the request, its response is sent to
+// responseChan if set, to onMessage otherwise
+func (t *JsonRpcTransport) sendRequest(request *jsonrpc.JsonRpcRequest, responseChan chan *jsonrpc.JsonRpcResponse) error {
 	jsonMessage, err := jsonrpc.MarshalJsonRpcRequest(request)
 	if err != nil {
 		t.logger.Error("error marshalling message", types.LogArg{
@@ -246,10 +316,13 @@ func (t *JsonRpcTransport) SendRequest(request *jsonrpc.JsonRpcRequest) error {
 	// we store the request in the pending requests map
 	// so we can match the response with the request
 	if request.Id != nil {
+		t.mu.Lock()
 		t.pendingRequests[jsonrpc.RequestIdToString(request.Id)] = &pendingRequest{
 			method:    request.Method,
 			requestId: request.Id,
+			response:  responseChan,
 		}
+		t.mu.Unlock()
 	}
 
 	t.logger.Info("sending request", types.LogArg{
@@ -271,6 +344,10 @@ func (t *JsonRpcTransport) SendResponse(response *jsonrpc.JsonRpcResponse) error
 		})
 		return err
 	}
+	// the response is sent with the others of its batch
+	if t.collectBatchResponse(response.Id, jsonMessage) {
+		return nil
+	}
 	return t.transport.Send(jsonMessage)
 }
 
@@ -299,6 +376,10 @@ func (t *JsonRpcTransport) SendError(code int, message string, id *jsonrpc.JsonR
 		})
 		return err
 	}
+	// the response is sent with the others of its batch
+	if t.collectBatchResponse(id, jsonMessage) {
+		return nil
+	}
 	// send the message
 	return t.transport.Send(jsonMessage)
 
@@ -312,13 +393,30 @@ func (t *JsonRpcTransport) SendNotification(method string) {
 	t.SendRequest(&notification)
 }
 
+func (t *JsonRpcTransport) SendNotificationWithParams(method string, params interface{}) error {
+	notification := buildJsonRpcRequestWithNamedParams(method, params, nil)
+	if notification == nil {
+		return fmt.Errorf("failed to create %s notification", method)
+	}
+	return t.SendRequest(notification)
+}
+
 func (t *JsonRpcTransport) Close() {
 	t.transport.Close()
+	t.markClosed()
+}
+
+func (t *JsonRpcTransport) markClosed() {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+	})
 }
 
 func (t *JsonRpcTransport) GetNextRequestId() *jsonrpc.JsonRpcRequestId {
+	t.mu.Lock()
 	requestId := t.lastRequestId
 	t.lastRequestId++
+	t.mu.Unlock()
 	return &jsonrpc.JsonRpcRequestId{
 		Number: &requestId,
 	}
@@ -327,20 +425,30 @@ func (t *JsonRpcTransport) GetNextRequestId() *jsonrpc.JsonRpcRequestId {
 // GetPendingRequest returns the method and message id of the pending request
 // if the request is not found, it returns an empty string and nil
 func (t *JsonRpcTransport) GetPendingRequest(reqId *jsonrpc.JsonRpcRequestId) (string, *jsonrpc.JsonRpcRequestId) {
-	if reqId == nil {
+	pending := t.takePendingRequest(reqId)
+	if pending == nil {
 		return "", nil
 	}
+	return pending.method, pending.requestId
+}
+
+// takePendingRequest removes the pending request from the map and returns it,
+// nil if the request is not found
+func (t *JsonRpcTransport) takePendingRequest(reqId *jsonrpc.JsonRpcRequestId) *pendingRequest {
+	if reqId == nil {
+		return nil
+	}
 	reqIdStr := jsonrpc.RequestIdToString(reqId)
-	pendingRequest := t.pendingRequests[reqIdStr]
-	if pendingRequest == nil {
-		t.logger.Error("pending request not found", types.LogArg{
+	t.mu.Lock()
+	pending := t.pendingRequests[reqIdStr]
+	delete(t.pendingRequests, reqIdStr)
+	t.mu.Unlock()
+	if pending == nil {
+		t.logger.Debug("pending request not found", types.LogArg{
 			"requestId": reqIdStr,
 		})
-		return "", nil
 	}
-	// we delete the pending request from the map
-	delete(t.pendingRequests, reqIdStr)
-	return pendingRequest.method, pendingRequest.requestId
+	return pending
 }
 
 func structToMap(obj interface{}) (map[string]interface{}, error) {
@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/llmcontext/gomcp/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// startJsonRpcTransport starts a JsonRpcTransport on an in-process transport,
+// the requests it sends are read from Outgoing
+func startJsonRpcTransport(t *testing.T) (*JsonRpcTransport, *InProcessTransport) {
+	inProcess := NewInProcessTransport()
+	jsonRpcTransport := NewJsonRpcTransport(inProcess, "test", logger.NewTeeLogger())
+	started := make(chan struct{})
+	jsonRpcTransport.OnStarted(func() {
+		close(started)
+	})
+	go jsonRpcTransport.Start(context.Background(), func(message JsonRpcMessage, _ *JsonRpcTransport) {})
+	<-started
+	return jsonRpcTransport, inProcess
+}
+
+func pendingRequestsCount(t *JsonRpcTransport) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pendingRequests)
+}
+
+func TestCallConcurrently(t *testing.T) {
+	jsonRpcTransport, inProcess := startJsonRpcTransport(t)
+	defer jsonRpcTransport.Close()
+
+	const calls = 20
+	results := make([]interface{}, calls)
+	var wg sync.WaitGroup
+	for index := 0; index < calls; index++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			response, err := jsonRpcTransport.Call(context.Background(), "echo", map[string]interface{}{"index": index})
+			if assert.NoError(t, err) {
+				results[index] = response.Result
+			}
+		}(index)
+	}
+
+	// the responses are sent in the reverse order of the requests
+	requests := []map[string]interface{}{}
+	for len(requests) < calls {
+		request := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(<-inProcess.Outgoing(), &request))
+		requests = append(requests, request)
+	}
+	for index := len(requests) - 1; index >= 0; index-- {
+		response := fmt.Sprintf(`{"jsonrpc":"2.0","id":%v,"result":%v}`, requests[index]["id"], requests[index]["params"].(map[string]interface{})["index"])
+		assert.NoError(t, inProcess.Deliver(json.RawMessage(response)))
+	}
+	wg.Wait()
+
+	for index, result := range results {
+		assert.Equal(t, float64(index), result)
+	}
+	assert.Equal(t, 0, pendingRequestsCount(jsonRpcTransport))
+}
+
+func TestCallErrorResponse(t *testing.T) {
+	jsonRpcTransport, inProcess := startJsonRpcTransport(t)
+	defer jsonRpcTransport.Close()
+
+	go func() {
+		request := map[string]interface{}{}
+		json.Unmarshal(<-inProcess.Outgoing(), &request)
+		inProcess.Deliver(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%v,"error":{"code":-32601,"message":"unknown method"}}`, request["id"])))
+	}()
+
+	response, err := jsonRpcTransport.Call(context.Background(), "unknown", struct{}{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, -32601, response.Error.Code)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	jsonRpcTransport, inProcess := startJsonRpcTransport(t)
+	defer jsonRpcTransport.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := jsonRpcTransport.Call(ctx, "slow", struct{}{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, pendingRequestsCount(jsonRpcTransport))
+
+	// the late response is dropped
+	request := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(<-inProcess.Outgoing(), &request))
+	assert.NoError(t, inProcess.Deliver(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%v,"result":{}}`, request["id"]))))
+}
+
+func TestCallTransportClosed(t *testing.T) {
+	jsonRpcTransport, _ := startJsonRpcTransport(t)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		jsonRpcTransport.Close()
+	}()
+	_, err := jsonRpcTransport.Call(context.Background(), "never", struct{}{})
+	assert.ErrorIs(t, err, ErrTransportClosed)
+	assert.Equal(t, 0, pendingRequestsCount(jsonRpcTransport))
+}
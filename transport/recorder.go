@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/trace"
+	"github.com/llmcontext/gomcp/types"
+)
+
+// RecordingTransport is a transport decorator writing every message
+// received or sent by the wrapped transport to a JSONL trace file
+type RecordingTransport struct {
+	transport types.Transport
+	sessionId string
+	mu        sync.Mutex
+	writer    io.Writer
+	closer    io.Closer
+	now       func() time.Time
+}
+
+// NewRecordingTransport records the messages of transport at the end of the
+// trace file, each session gets a random id so that several runs can share a file
+func NewRecordingTransport(transport types.Transport, traceFilePath string) (*RecordingTransport, error) {
+	file, err := os.OpenFile(traceFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %v", err)
+	}
+	recorder := NewRecordingTransportWithWriter(transport, file, NewSessionId())
+	recorder.closer = file
+	return recorder, nil
+}
+
+func NewRecordingTransportWithWriter(transport types.Transport, writer io.Writer, sessionId string) *RecordingTransport {
+	return &RecordingTransport{
+		transport: transport,
+		sessionId: sessionId,
+		writer:    writer,
+		now:       time.Now,
+	}
+}
+
+func (t *RecordingTransport) SessionId() string {
+	return t.sessionId
+}
+
+func (t *RecordingTransport) Start(ctx context.Context) error {
+	return t.transport.Start(ctx)
+}
+
+func (t *RecordingTransport) Send(message json.RawMessage) error {
+	t.record(trace.DirectionOutbound, message)
+	return t.transport.Send(message)
+}
+
+func (t *RecordingTransport) OnMessage(callback func(json.RawMessage)) {
+	t.transport.OnMessage(func(message json.RawMessage) {
+		t.record(trace.DirectionInbound, message)
+		callback(message)
+	})
+}
+
+func (t *RecordingTransport) Close() {
+	t.transport.Close()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closer != nil {
+		t.closer.Close()
+		t.closer = nil
+		t.writer = nil
+	}
+}
+
+func (t *RecordingTransport) OnStarted(callback func()) {
+	t.transport.OnStarted(callback)
+}
+
+func (t *RecordingTransport) OnClose(callback func()) {
+	t.transport.OnClose(callback)
+}
+
+func (t *RecordingTransport) OnError(callback func(error)) {
+	t.transport.OnError(callback)
+}
+
+// recording errors are ignored: the trace must not break the protocol
+func (t *RecordingTransport) record(direction trace.Direction, message json.RawMessage) {
+	entry := trace.Entry{
+		Timestamp: t.now().UTC(),
+		Direction: direction,
+		SessionId: t.sessionId,
+	}
+	if json.Valid(message) {
+		entry.Message = message
+	} else {
+		entry.Raw = string(message)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.writer == nil {
+		return
+	}
+	t.writer.Write(line)
+}
+
+func NewSessionId() string {
+	buffer := make([]byte, 8)
+	if _, err := rand.Read(buffer); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buffer)
+}
@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/llmcontext/gomcp/pkg/trace"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubTransport delivers the messages passed to receive
+type stubTransport struct {
+	onMessage func(json.RawMessage)
+	sent      []json.RawMessage
+}
+
+func (t *stubTransport) Start(ctx context.Context) error { return nil }
+func (t *stubTransport) Send(message json.RawMessage) error {
+	t.sent = append(t.sent, message)
+	return nil
+}
+func (t *stubTransport) OnMessage(callback func(json.RawMessage)) { t.onMessage = callback }
+func (t *stubTransport) Close()                                   {}
+func (t *stubTransport) OnStarted(callback func())                {}
+func (t *stubTransport) OnClose(callback func())                  {}
+func (t *stubTransport) OnError(callback func(error))             {}
+func (t *stubTransport) receive(message string)                   { t.onMessage(json.RawMessage(message)) }
+
+func TestRecordingTransport(t *testing.T) {
+	stub := &stubTransport{}
+	var buffer bytes.Buffer
+	recorder := NewRecordingTransportWithWriter(stub, &buffer, "session-1")
+
+	received := []string{}
+	recorder.OnMessage(func(message json.RawMessage) {
+		received = append(received, string(message))
+	})
+
+	stub.receive(`{"jsonrpc": "2.0", "method": "tools/list", "id": 1}`)
+	stub.receive(`not json`)
+	assert.NoError(t, recorder.Send(json.RawMessage(`{"jsonrpc":"2.0","result":{"tools":[]},"id":1}`)))
+
+	// the messages go through untouched
+	assert.Len(t, received, 2)
+	assert.Len(t, stub.sent, 1)
+
+	entries, err := trace.ReadTrace(&buffer)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, trace.DirectionInbound, entries[0].Direction)
+	assert.Equal(t, "session-1", entries[0].SessionId)
+	assert.Equal(t, "request tools/list id=1", trace.Summary(entries[0]))
+	assert.Equal(t, "not json", entries[1].Raw)
+	assert.Equal(t, trace.DirectionOutbound, entries[2].Direction)
+	assert.Equal(t, "response id=1", trace.Summary(entries[2]))
+
+	var output strings.Builder
+	assert.NoError(t, trace.PrettyPrint(&output, entries))
+	assert.Contains(t, output.String(), "[session-1] << out response id=1")
+}
@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/llmcontext/gomcp/types"
 )
 
 type StdioTransport struct {
+	// serializes the writes to stdout
+	sendMutex sync.Mutex
 	isClosed  bool
 	logger    types.Logger
 	onStarted func()
@@ -46,14 +49,8 @@ func (t *StdioTransport) Start(ctx context.Context) error {
 func (t *StdioTransport) Send(message json.RawMessage) error {
 	// Write message followed by newline to stdout
 
-	// if t.inspector != nil {
-	// 	t.inspector.EnqueueMessage(hubinspector.MessageInfo{
-	// 		Timestamp: time.Now().Format(time.RFC3339),
-	// 		Direction: hubinspector.MessageDirectionResponse,
-	// 		Content:   string(message),
-	// 	})
-	// }
-
+	t.sendMutex.Lock()
+	defer t.sendMutex.Unlock()
 	_, err := fmt.Fprintf(os.Stdout, "%s\n", message)
 	return err
 }
@@ -109,13 +106,6 @@ func (t *StdioTransport) readLoop(ctx context.Context, errChan chan error) {
 			default:
 				line := scanner.Text()
 				if t.onMessage != nil {
-					// if t.inspector != nil {
-					// 	t.inspector.EnqueueMessage(hubinspector.MessageInfo{
-					// 		Timestamp: time.Now().Format(time.RFC3339),
-					// 		Direction: hubinspector.MessageDirectionRequest,
-					// 		Content:   line,
-					// 	})
-					// }
 					t.onMessage(json.RawMessage(line))
 				}
 			}
@@ -129,33 +119,3 @@ func (t *StdioTransport) readLoop(ctx context.Context, errChan chan error) {
 		errChan <- fmt.Errorf("MCP client closed the connection")
 	}()
 }
-
-// func (t *StdioTransport) logProtocolMessages(rawMessage string, direction string) {
-// 	// open log file and append
-// 	file, err := os.OpenFile(t.protocolDebugFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-// 	if err != nil {
-// 		t.logger.Error("error opening protocol debug file", types.LogArg{"error": err})
-// 	}
-// 	defer file.Close()
-
-// 	// write to file
-// 	_, err = file.WriteString(fmt.Sprintf(":%s: %s\n", direction, rawMessage))
-// 	if err != nil {
-// 		t.logger.Error("error writing to protocol debug file", types.LogArg{"error": err})
-// 	}
-
-// 	// try to parse the message as JSON
-// 	var jsonMessage json.RawMessage
-// 	err = json.Unmarshal([]byte(rawMessage), &jsonMessage)
-// 	if err != nil {
-// 		file.WriteString(fmt.Sprintf("!error parsing message as JSON: %s\n", err))
-// 	} else {
-// 		// pretty print the JSON message
-// 		prettyJSON, err := json.MarshalIndent(jsonMessage, "", "  ")
-// 		if err != nil {
-// 			file.WriteString(fmt.Sprintf("!error formatting json message: %s\n", err))
-// 		} else {
-// 			file.WriteString(fmt.Sprintf("%s\n", string(prettyJSON)))
-// 		}
-// 	}
-// }
@@ -0,0 +1,19 @@
+package types
+
+import "context"
+
+// CompletionRequest is an argument of a prompt or of a resource
+// template the client wants to complete
+type CompletionRequest struct {
+	// the name of the prompt, empty for a resource template
+	PromptName string
+	// the uri of the resource template, empty for a prompt
+	ResourceUri  string
+	ArgumentName string
+	// what the user typed so far
+	Value string
+}
+
+// CompletionHandler returns the suggested values for the argument,
+// the static values of the prompt argument are used if it returns nil
+type CompletionHandler func(ctx context.Context, request *CompletionRequest) ([]string, error)
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrElicitationNotSupported is returned by Elicit when the client did not
+// advertise the elicitation capability or uses an older protocol version
+var ErrElicitationNotSupported = errors.New("the client does not support elicitation")
+
+// ElicitAction is the answer of the user to an elicitation request
+type ElicitAction string
+
+const (
+	// the user submitted the form, the content is set
+	ElicitActionAccept ElicitAction = "accept"
+	// the user explicitly refused to give the information
+	ElicitActionDecline ElicitAction = "decline"
+	// the user dismissed the request without choosing
+	ElicitActionCancel ElicitAction = "cancel"
+)
+
+// Elicit asks the user of the client of the request being handled to fill
+// the fields of content, a pointer to a struct. The schema sent to the client
+// is reflected from the struct, like the input of the tools, its fields can
+// only be strings, numbers or booleans. The content is only set if the user
+// accepts. It blocks until the user answers or until the context is done.
+func Elicit(ctx context.Context, message string, content interface{}) (ElicitAction, error) {
+	session := GetClientSession(ctx)
+	if session == nil {
+		return "", ErrElicitationNotSupported
+	}
+	return session.Elicit(ctx, message, content)
+}
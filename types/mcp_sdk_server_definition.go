@@ -1,13 +1,36 @@
 package types
 
-import "github.com/llmcontext/gomcp/pkg/prompts"
+import (
+	"io/fs"
+	"text/template"
+	"time"
+
+	"github.com/llmcontext/gomcp/pkg/prompts"
+)
 
 type ToolsDefinition interface {
 	AddTool(toolName string, description string, toolHandler interface{}) error
 }
 
+// ToolProvider is a named set of tools, the name is used to
+// find the provider configuration in the server configuration file
+type ToolProvider interface {
+	ToolsDefinition
+	ProviderName() string
+}
+
 type McpSdkServerDefinition interface {
 	SetDebugLevel(debugLevel string, debugFile string)
+	SetLogger(logger Logger)
+	SetProtocolDebugFile(protocolDebugFile string)
+	EnableInspector(listenAddress string)
 	WithTools(configuration interface{}, toolsInitFunction interface{}) ToolsDefinition
+	SetToolsInitBackoff(minBackoff time.Duration, maxBackoff time.Duration)
+	SetListPageSize(pageSize int)
 	AddTemplateYamlFile(templateYamlFilePath string) ([]*prompts.DuplicatedPrompt, error)
+	AddTemplateFS(fsys fs.FS, pattern string) ([]*prompts.DuplicatedPrompt, error)
+	WatchPromptFiles(interval time.Duration)
+	AddPromptFuncs(funcs template.FuncMap)
+	AddPrompt(promptName string, description string, promptHandler interface{}) error
+	SetCompletionHandler(handler CompletionHandler)
 }
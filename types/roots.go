@@ -0,0 +1,111 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// ErrRootsNotSupported is returned by GetRoots when the client
+// did not advertise the roots capability
+var ErrRootsNotSupported = errors.New("the client does not support roots")
+
+// Root is a directory or a file the client gives access to, eg. a
+// project opened by the user
+type Root struct {
+	Uri  string
+	Name string
+}
+
+// Path returns the local path of a file:// root
+func (r Root) Path() (string, error) {
+	parsed, err := url.Parse(r.Uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid root uri %s: %v", r.Uri, err)
+	}
+	if parsed.Scheme != "file" {
+		return "", fmt.Errorf("invalid root uri %s: not a file uri", r.Uri)
+	}
+	path := parsed.Path
+	// file:///C:/projects on windows
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(path)), nil
+}
+
+// Contains checks whether the path is the root or is inside the root,
+// a relative path is resolved against the working directory of the server.
+// The symbolic links are resolved on both sides, so that a link inside
+// the root can't give access to a file outside of it.
+func (r Root) Contains(path string) bool {
+	rootPath, err := r.Path()
+	if err != nil {
+		return false
+	}
+	rootPath, err = resolvePath(rootPath)
+	if err != nil {
+		return false
+	}
+	resolvedPath, err := resolvePath(path)
+	if err != nil {
+		return false
+	}
+	relative, err := filepath.Rel(rootPath, resolvedPath)
+	if err != nil {
+		return false
+	}
+	return relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
+
+// resolvePath returns the absolute path without symbolic links. The path
+// may not exist yet, eg. a file to create, its nearest existing parent
+// is resolved and the missing elements are appended
+func resolvePath(path string) (string, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	missing := ""
+	current := absolutePath
+	for {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(resolved, missing), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return absolutePath, nil
+		}
+		missing = filepath.Join(filepath.Base(current), missing)
+		current = parent
+	}
+}
+
+// PathInRoots checks whether the path is inside one of the roots
+func PathInRoots(roots []Root, path string) bool {
+	for _, root := range roots {
+		if root.Contains(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRoots returns the roots of the client that sent the request being
+// handled. The list is empty until the client answers the first roots/list
+// request, sent once the session is initialized.
+func GetRoots(ctx context.Context) ([]Root, error) {
+	session := GetClientSession(ctx)
+	if session == nil {
+		return nil, ErrRootsNotSupported
+	}
+	return session.Roots()
+}
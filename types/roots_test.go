@@ -0,0 +1,59 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootContains(t *testing.T) {
+	root := Root{Uri: "file:///home/user/project"}
+
+	assert.True(t, root.Contains("/home/user/project"))
+	assert.True(t, root.Contains("/home/user/project/src/main.go"))
+	assert.True(t, root.Contains("/home/user/project/../project/main.go"))
+	assert.False(t, root.Contains("/home/user/project2/main.go"))
+	assert.False(t, root.Contains("/home/user/project/../secrets"))
+	assert.False(t, root.Contains("/etc/passwd"))
+
+	// only file uris can be checked
+	assert.False(t, Root{Uri: "https://example.com/project"}.Contains("/home/user/project"))
+}
+
+func TestRootContainsSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project")
+	secrets := filepath.Join(dir, "secrets")
+	assert.NoError(t, os.Mkdir(project, 0755))
+	assert.NoError(t, os.Mkdir(secrets, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(secrets, "token"), []byte("ntn_123"), 0600))
+	if err := os.Symlink(secrets, filepath.Join(project, "link")); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	assert.NoError(t, os.Symlink(project, filepath.Join(dir, "shortcut")))
+
+	root := Root{Uri: "file://" + filepath.ToSlash(project)}
+	assert.True(t, root.Contains(filepath.Join(project, "main.go")))
+	// a link inside the root pointing outside of it
+	assert.False(t, root.Contains(filepath.Join(project, "link", "token")))
+	assert.False(t, root.Contains(filepath.Join(project, "link", "new", "file")))
+	// a link outside the root pointing inside of it
+	assert.True(t, root.Contains(filepath.Join(dir, "shortcut", "main.go")))
+
+	// the root itself is a link
+	shortcut := Root{Uri: "file://" + filepath.ToSlash(filepath.Join(dir, "shortcut"))}
+	assert.True(t, shortcut.Contains(filepath.Join(project, "main.go")))
+	assert.False(t, shortcut.Contains(filepath.Join(secrets, "token")))
+}
+
+func TestPathInRoots(t *testing.T) {
+	roots := []Root{
+		{Uri: "file:///home/user/project"},
+		{Uri: "file:///tmp/build", Name: "build"},
+	}
+	assert.True(t, PathInRoots(roots, "/tmp/build/out"))
+	assert.False(t, PathInRoots(roots, "/tmp/other"))
+	assert.False(t, PathInRoots(nil, "/tmp/build/out"))
+}
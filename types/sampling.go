@@ -0,0 +1,62 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSamplingNotSupported is returned by CreateMessage when the client
+// did not advertise the sampling capability
+var ErrSamplingNotSupported = errors.New("the client does not support sampling")
+
+// the types of the sampling contents
+const (
+	SamplingContentText  = "text"
+	SamplingContentImage = "image"
+	SamplingContentAudio = "audio"
+)
+
+// SamplingMessage is a message of the conversation sent to the model of the client
+type SamplingMessage struct {
+	// "user" or "assistant"
+	Role string
+	// SamplingContentText by default
+	Type string
+	Text string
+	// the base64 encoded data of an image or an audio content
+	Data     string
+	MimeType string
+}
+
+// ModelPreferences helps the client to select a model, all the fields are optional
+type ModelPreferences struct {
+	// names or families of models, eg. "claude-3-5-sonnet" or "claude"
+	Hints []string
+	// the priorities are between 0 and 1
+	CostPriority         *float64
+	SpeedPriority        *float64
+	IntelligencePriority *float64
+}
+
+// SamplingResult is the message generated by the model of the client
+type SamplingResult struct {
+	Role     string
+	Type     string
+	Text     string
+	Data     string
+	MimeType string
+	// the model used by the client
+	Model      string
+	StopReason string
+}
+
+// CreateMessage asks the client of the request being handled to generate a
+// message with its model. It blocks until the client answers, the user may
+// have to approve the request, or until the context is done.
+func CreateMessage(ctx context.Context, messages []SamplingMessage, modelPreferences *ModelPreferences, maxTokens int) (*SamplingResult, error) {
+	session := GetClientSession(ctx)
+	if session == nil {
+		return nil, ErrSamplingNotSupported
+	}
+	return session.CreateMessage(ctx, messages, modelPreferences, maxTokens)
+}
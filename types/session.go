@@ -0,0 +1,57 @@
+package types
+
+import "context"
+
+// ClientCapabilities are the features advertised by the client
+// during the initialization of the session
+type ClientCapabilities struct {
+	Roots bool
+	// the client notifies the server when the list of roots changes
+	RootsListChanged bool
+	Sampling         bool
+	Elicitation      bool
+	// non-standard capabilities, as sent by the client
+	Experimental map[string]interface{}
+}
+
+// ClientSession describes the client connected to the server
+type ClientSession interface {
+	ClientName() string
+	ClientVersion() string
+	// the version negotiated during the initialization
+	ProtocolVersion() string
+	Capabilities() *ClientCapabilities
+	// the roots of the client, ErrRootsNotSupported if the
+	// client does not support them
+	Roots() ([]Root, error)
+	// see the CreateMessage function
+	CreateMessage(ctx context.Context, messages []SamplingMessage, modelPreferences *ModelPreferences, maxTokens int) (*SamplingResult, error)
+	// see the Elicit function
+	Elicit(ctx context.Context, message string, content interface{}) (ElicitAction, error)
+}
+
+var clientSessionKey = contextKey("clientSession")
+
+func ContextWithClientSession(ctx context.Context, session ClientSession) context.Context {
+	return context.WithValue(ctx, clientSessionKey, session)
+}
+
+// GetClientSession returns the session of the client that sent the
+// request being handled, nil outside of a request
+func GetClientSession(ctx context.Context) ClientSession {
+	session := ctx.Value(clientSessionKey)
+	if session == nil {
+		return nil
+	}
+	return session.(ClientSession)
+}
+
+// GetClientCapabilities returns the capabilities of the client that sent the
+// request being handled, none are set outside of a request
+func GetClientCapabilities(ctx context.Context) *ClientCapabilities {
+	session := GetClientSession(ctx)
+	if session == nil {
+		return &ClientCapabilities{}
+	}
+	return session.Capabilities()
+}
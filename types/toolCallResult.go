@@ -8,3 +8,21 @@ type ToolCallResult interface {
 	AddEmbeddedResourceBlobContent(uri string, mimeType string, base64Data string)
 	SetError(isError bool)
 }
+
+// ExtendedToolCallResult is implemented by the results passed to the tools,
+// it holds the contents added by the later protocol versions. It is not part
+// of ToolCallResult so that the existing implementations keep compiling, the
+// tools get it with a type assertion:
+//
+//	if extended, ok := output.(types.ExtendedToolCallResult); ok {
+//		extended.SetStructuredContent(result)
+//	}
+type ExtendedToolCallResult interface {
+	ToolCallResult
+	// audio is only sent to the clients using the protocol version 2025-03-26 or later
+	AddAudioContent(mimeType string, base64Data string)
+	// SetStructuredContent sets the structuredContent of the result, sent to
+	// the clients using the protocol version 2025-06-18 or later, and adds its
+	// JSON as a text content for the other clients
+	SetStructuredContent(content interface{})
+}